@@ -33,7 +33,7 @@ import (
 const (
 	queryCreateTestTable       = `CREATE TABLE %s (id int, name VARCHAR(100))`
 	queryDropTestTable         = `DROP TABLE IF EXISTS %s`
-	queryFindTrackingTableName = `SELECT TABNAME FROM  SysCat.Tables WHERE TabName LIKE 'CONDUIT_%s_%%' LIMIT 1`
+	queryFindTrackingTableName = `SELECT TABNAME FROM  SysCat.Tables WHERE TabName LIKE 'CONDUIT_%s_%%' FETCH FIRST 1 ROWS ONLY`
 )
 
 type driver struct {
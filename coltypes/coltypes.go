@@ -18,13 +18,17 @@ package coltypes
 import (
 	"context"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/conduitio-labs/conduit-connector-db2/common"
 	"github.com/conduitio/conduit-commons/opencdc"
 )
 
@@ -40,41 +44,69 @@ const (
 	varcharType        = "VARCHAR"
 	longVarGraphicType = "LONG VARGRAPHIC"
 	varGraphicType     = "VARGRAPHIC"
-	decimalType        = "DECIMAL"
-	decimalFloatType   = "DECFLOAT"
 	dbClobType         = "DBCLOB"
+	xmlType            = "XML"
+
+	// Numeric types.
+	smallintType     = "SMALLINT"
+	integerType      = "INTEGER"
+	bigintType       = "BIGINT"
+	realType         = "REAL"
+	doubleType       = "DOUBLE"
+	decimalType      = "DECIMAL"
+	decimalFloatType = "DECFLOAT"
+
+	// Boolean type.
+	booleanType = "BOOLEAN"
 
 	// Time types.
-	dateType  = "DATE"
-	timeType  = "TIME"
-	timeStamp = "TIMESTAMP"
+	dateType            = "DATE"
+	timeType            = "TIME"
+	timeStamp           = "TIMESTAMP"
+	timeStampTZ         = "TIMESTAMP WITH TIME ZONE"
+	timeStampTZShortcut = "TIMESTZ"
 
 	// Binary types.
 	binaryType    = "BINARY"
 	varbinaryType = "VARBINARY"
 	blobType      = "BLOB"
+	rowIDType     = "ROWID"
+
+	// codePageBitData is a code page of CHAR and VARCHAR FOR BIT DATA columns.
+	codePageBitData = 0
+	// maxNanosecondsScale is the maximum TIMESTAMP precision that fits time.Time.
+	maxNanosecondsScale = 9
 )
 
 var (
 	// querySchemaColumnTypes is a query that selects column names and
 	// their data and column types from the information_schema.
+	// Distinct types are resolved to their source types.
 	querySchemaColumnTypes = `
 			SELECT 
-				   colname AS column_name,
-				   typename AS data_type,
-				   length,			   
-				   keyseq 
-			FROM syscat.columns
-			WHERE tabname = '%s'
+				   c.colname AS column_name,
+				   COALESCE(d.sourcename, c.typename) AS data_type,
+				   c.length,
+				   c.scale,
+				   c.codepage,
+				   c.keyseq 
+			FROM syscat.columns c
+			LEFT JOIN syscat.datatypes d
+				ON d.typeschema = c.typeschema
+				AND d.typename = c.typename
+				AND d.metatype = 'T'
+				AND c.typeschema <> 'SYSIBM'
+			WHERE c.tabname = ?
 `
-	// time layouts.
-	layouts = []string{time.RFC3339, time.RFC3339Nano, time.Layout, time.ANSIC, time.UnixDate, time.RubyDate,
-		time.RFC822, time.RFC822Z, time.RFC850, time.RFC1123, time.RFC1123Z, time.RFC3339, time.RFC3339,
-		time.RFC3339Nano, time.Kitchen, time.Stamp, time.StampMilli, time.StampMicro, time.StampNano}
+	// integerBitSizes - bit sizes of integer types.
+	integerBitSizes = map[string]int{smallintType: 16, integerType: 32, bigintType: 64}
 
 	// column types where length is required parameter.
 	typesWithLength = []string{charType, varcharType, clobType, graphicType, varGraphicType, dbClobType,
 		binaryType, varbinaryType, blobType}
+
+	// types of columns which can be defined as FOR BIT DATA.
+	typesForBitData = []string{charType, varcharType, longVarcharType}
 )
 
 // Querier is a database querier interface needed for the GetTableInfo function.
@@ -87,37 +119,138 @@ type TableInfo struct {
 	// ColumnTypes - column name with column type.
 	ColumnTypes map[string]string
 	// ColumnLengths - column name with length
+	// (the precision for DECIMAL columns).
 	ColumnLengths map[string]int
-	// PrimaryKeys - primary keys column names.
+	// ColumnScales - column name with scale
+	// (the fractional seconds precision for TIMESTAMP columns).
+	ColumnScales map[string]int
+	// ForBitData - names of CHAR and VARCHAR FOR BIT DATA columns.
+	ForBitData map[string]bool
+	// PrimaryKeys - column names of the primary key, in the order of the key.
 	PrimaryKeys []string
 }
 
 func (t TableInfo) GetCreateColumnStr() string {
 	var columns []string
 	for key, val := range t.ColumnTypes {
-		cl := fmt.Sprintf("%s %s", key, val)
-		if isTypeWithRequiredLength(val) {
+		cl := fmt.Sprintf("%s %s", common.QuoteIdentifier(key), val)
+		switch {
+		case val == decimalType:
+			cl = fmt.Sprintf("%s(%d,%d)", cl, t.ColumnLengths[key], t.ColumnScales[key])
+		case val == timeStamp:
+			cl = fmt.Sprintf("%s(%d)", cl, t.ColumnScales[key])
+		case isTimestampWithTimeZone(val):
+			cl = fmt.Sprintf("%s %s(%d) WITH TIME ZONE", common.QuoteIdentifier(key), timeStamp, t.ColumnScales[key])
+		case isTypeWithRequiredLength(val):
 			cl = fmt.Sprintf("%s(%d)", cl, t.ColumnLengths[key])
 		}
 
+		if t.ForBitData[key] {
+			cl += " FOR BIT DATA"
+		}
+
 		columns = append(columns, cl)
 	}
 
 	return strings.Join(columns, ",")
 }
 
-func isTypeWithRequiredLength(elem string) bool {
-	for _, val := range typesWithLength {
-		if val == elem {
-			return true
+// SelectColumns returns expressions for selecting the provided columns, or all columns of the table
+// if the provided list is empty. Column types that the driver can't return without loss are cast to strings,
+// LOB columns are selected according to their policies. Columns are delimited identifiers, so names
+// of the result columns are the column names.
+func (t TableInfo) SelectColumns(columns []string, lobPolicies LOBPolicies) []string {
+	if len(columns) == 0 {
+		columns = make([]string, 0, len(t.ColumnTypes))
+		for column := range t.ColumnTypes {
+			columns = append(columns, column)
+		}
+
+		sort.Strings(columns)
+	}
+
+	result := make([]string, 0, len(columns))
+	for _, column := range columns {
+		switch colType := t.ColumnTypes[column]; {
+		case isTimestampWithTimeZone(colType),
+			colType == timeStamp && t.ColumnScales[column] > maxNanosecondsScale:
+			quoted := common.QuoteIdentifier(column)
+			result = append(result, fmt.Sprintf("VARCHAR(%s) AS %s", quoted, quoted))
+		case isLOB(colType):
+			if expr, ok := selectLOB(column, colType, lobPolicies.policy(column)); ok {
+				result = append(result, expr)
+			}
+		default:
+			result = append(result, common.QuoteIdentifier(column))
 		}
 	}
 
-	return false
+	return result
 }
 
-// TransformRow converts row map values to appropriate Go types, based on the columnTypes.
-func TransformRow(_ context.Context, row map[string]any, columnTypes map[string]string) (map[string]any, error) {
+// Column returns the name of the column the field refers to: the column with exactly the same name, or else
+// the column whose name matches it case-insensitively, preferring the uppercase name DB2 folds ordinary
+// identifiers to. Fields which match no column are returned unchanged.
+func (t TableInfo) Column(field string) string {
+	if _, ok := t.ColumnTypes[field]; ok {
+		return field
+	}
+
+	if upper := strings.ToUpper(field); t.ColumnTypes[upper] != "" {
+		return upper
+	}
+
+	matches := make([]string, 0, 1)
+	for column := range t.ColumnTypes {
+		if strings.EqualFold(column, field) {
+			matches = append(matches, column)
+		}
+	}
+
+	if len(matches) == 0 {
+		return field
+	}
+
+	return slices.Min(matches)
+}
+
+func isTypeWithRequiredLength(elem string) bool {
+	return slices.Contains(typesWithLength, elem)
+}
+
+func isTimestampWithTimeZone(elem string) bool {
+	return elem == timeStampTZ || elem == timeStampTZShortcut
+}
+
+// TransformOptions holds options for converting row values in the TransformRow function.
+type TransformOptions struct {
+	// DecimalFormat is a representation of DECIMAL values.
+	DecimalFormat DecimalFormat
+	// TimeFormat is a representation of DATE, TIME and TIMESTAMP values.
+	TimeFormat TimeFormat
+	// Location is a time zone of the DB2 session, used for values without time zone.
+	Location *time.Location
+	// LOBPolicies are policies of reading CLOB, BLOB and DBCLOB columns.
+	LOBPolicies LOBPolicies
+}
+
+// ConvertOptions holds options for converting values in the ConvertStructureData function.
+type ConvertOptions struct {
+	// Location is a time zone of the DB2 session, used for values without time zone.
+	Location *time.Location
+	// BinaryEncoding is an encoding of string values of binary columns.
+	BinaryEncoding BinaryEncoding
+	// LOBMaxBytes is a maximum size of CLOB, BLOB and DBCLOB values, zero means no limit.
+	LOBMaxBytes int
+}
+
+// TransformRow converts row map values to appropriate Go types, based on the tableInfo.
+func TransformRow(
+	_ context.Context,
+	row map[string]any,
+	tableInfo TableInfo,
+	opts TransformOptions,
+) (map[string]any, error) {
 	result := make(map[string]any, len(row))
 
 	for key, value := range row {
@@ -127,34 +260,107 @@ func TransformRow(_ context.Context, row map[string]any, columnTypes map[string]
 			continue
 		}
 
-		switch columnTypes[key] {
-		// Convert to string.
-		case charType, clobType, longVarcharType, graphicType, longVarGraphicType,
-			varcharType, varGraphicType, decimalType, decimalFloatType:
-			valueBytes, ok := value.([]byte)
-			if !ok {
-				return nil, convertValueToBytesErr(key)
-			}
+		transformed, err := transformValue(key, value, tableInfo, opts)
+		if err != nil {
+			return nil, err
+		}
 
-			result[key] = string(valueBytes)
+		result[key] = transformed
+	}
 
-		default:
-			result[key] = value
+	return result, nil
+}
+
+// transformValue converts a value returned by the driver to an appropriate Go type, based on the column type.
+func transformValue(column string, value any, tableInfo TableInfo, opts TransformOptions) (any, error) {
+	colType := columnType(column, tableInfo)
+
+	// Hashed LOB values are binary regardless of the column type.
+	if isLOB(colType) && opts.LOBPolicies.policy(column).Type == LOBPolicyHash {
+		valueBytes, err := toBytes(column, value)
+		if err != nil {
+			return nil, err
 		}
+
+		return hex.EncodeToString(valueBytes), nil
 	}
 
-	return result, nil
+	switch colType {
+	// Convert to string.
+	case charType, clobType, longVarcharType, graphicType, longVarGraphicType,
+		varcharType, varGraphicType, dbClobType, xmlType, decimalFloatType:
+		valueBytes, err := toBytes(column, value)
+		if err != nil {
+			return nil, err
+		}
+
+		return string(valueBytes), nil
+
+	case decimalType:
+		valueBytes, err := toBytes(column, value)
+		if err != nil {
+			return nil, err
+		}
+
+		decimalValue, err := formatDecimal(valueBytes, tableInfo.ColumnScales[column], opts.DecimalFormat)
+		if err != nil {
+			return nil, fmt.Errorf("format decimal %q: %w", column, err)
+		}
+
+		return decimalValue, nil
+
+	case booleanType:
+		boolValue, err := convertBoolean(value)
+		if err != nil {
+			return nil, fmt.Errorf("convert %q to boolean: %w", column, err)
+		}
+
+		return boolValue, nil
+
+	case dateType, timeType, timeStamp:
+		timeValue, ok := value.(time.Time)
+		if ok {
+			return formatTime(colType, inLocation(timeValue, opts.Location), opts.TimeFormat), nil
+		}
+
+		fallthrough
+
+	// Timestamps which don't fit time.Time are selected as strings.
+	case timeStampTZ, timeStampTZShortcut:
+		valueBytes, err := toBytes(column, value)
+		if err != nil {
+			return nil, err
+		}
+
+		timestampValue, err := transformTimestamp(string(valueBytes), tableInfo.ColumnScales[column], opts)
+		if err != nil {
+			return nil, fmt.Errorf("transform timestamp %q: %w", column, err)
+		}
+
+		return timestampValue, nil
+
+	default:
+		return value, nil
+	}
 }
 
 // ConvertStructureData converts a sdk.StructureData values to a proper database types.
+// The result is keyed by the columns of the fields, see TableInfo.Column, fields of the same column
+// are reported as an error.
 func ConvertStructureData(
 	_ context.Context,
-	columnTypes map[string]string,
+	tableInfo TableInfo,
 	data opencdc.StructuredData,
+	opts ConvertOptions,
 ) (opencdc.StructuredData, error) {
 	result := make(opencdc.StructuredData, len(data))
 
-	for key, value := range data {
+	for field, value := range data {
+		key := tableInfo.Column(field)
+		if _, ok := result[key]; ok {
+			return nil, fmt.Errorf("field %q, column %q: %w", field, key, ErrDuplicateColumn)
+		}
+
 		if value == nil {
 			result[key] = value
 
@@ -165,6 +371,11 @@ func ConvertStructureData(
 		// DB2 string types can replace it.
 		switch reflect.TypeOf(value).Kind() {
 		case reflect.Map, reflect.Slice:
+			// Byte slices are binary or Avro decimal values.
+			if _, ok := value.([]byte); ok {
+				break
+			}
+
 			bs, err := json.Marshal(value)
 			if err != nil {
 				return nil, fmt.Errorf("marshal: %w", err)
@@ -175,76 +386,215 @@ func ConvertStructureData(
 			continue
 		}
 
-		// Converting value to time if it is string.
-		switch columnTypes[strings.ToUpper(key)] {
-		case dateType, timeType, timeStamp:
-			_, ok := value.(time.Time)
-			if ok {
-				result[key] = value
+		converted, err := convertValue(key, value, tableInfo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("convert %q: %w", field, err)
+		}
+
+		result[key] = converted
+	}
 
-				continue
-			}
+	return result, nil
+}
 
-			valueStr, ok := value.(string)
-			if !ok {
-				return nil, ErrValueIsNotAString
-			}
+// convertValue converts a value to a proper database type, based on the column type.
+func convertValue(column string, value any, tableInfo TableInfo, opts ConvertOptions) (any, error) {
+	switch colType := columnType(column, tableInfo); colType {
+	case binaryType, varbinaryType, rowIDType:
+		return convertBinary(value, opts.BinaryEncoding, 0)
 
-			timeValue, err := parseTime(valueStr)
-			if err != nil {
-				return nil, fmt.Errorf("convert value to time.Time: %w", err)
-			}
+	case blobType:
+		return convertBinary(value, opts.BinaryEncoding, opts.LOBMaxBytes)
 
-			result[key] = timeValue
-		// DecimalFloat must be a number.
-		case decimalFloatType:
-			switch v := value.(type) {
-			case float64:
-				result[key] = v
-			case float32:
-				result[key] = v
-			case int64:
-				result[key] = value.(float64)
-			case int32:
-				result[key] = value.(float32)
-			case string:
-				res, err := strconv.ParseFloat(value.(string), 64)
-				if err != nil {
-					return nil, fmt.Errorf("parse float: %w", err)
-				}
-
-				result[key] = res
-			default:
-				return nil, ErrConvertDecFloat
-			}
+	case charType, longVarcharType, graphicType, longVarGraphicType,
+		varcharType, varGraphicType, xmlType:
+		return convertString(value)
 
-		case binaryType, varbinaryType, blobType:
-			_, ok := value.([]byte)
-			if ok {
-				result[key] = value
+	case clobType, dbClobType:
+		stringValue, err := convertString(value)
+		if err != nil {
+			return nil, err
+		}
 
-				continue
-			}
+		if err = checkLOBSize(len(stringValue), opts.LOBMaxBytes); err != nil {
+			return nil, err
+		}
 
-			valueStr, ok := value.(string)
-			if !ok {
-				return nil, ErrValueIsNotAString
-			}
+		return stringValue, nil
+
+	// Converting value to time if it is string or epoch milliseconds.
+	case dateType, timeType:
+		return convertTime(value, colType, opts.Location)
+
+	case timeStamp:
+		if tableInfo.ColumnScales[column] > maxNanosecondsScale {
+			return convertTimestampString(value, tableInfo.ColumnScales[column], false, opts.Location)
+		}
+
+		return convertTime(value, colType, opts.Location)
+
+	case timeStampTZ, timeStampTZShortcut:
+		return convertTimestampString(value, tableInfo.ColumnScales[column], true, opts.Location)
+
+	case booleanType:
+		return convertBoolean(value)
+
+	// Numeric values are converted without going through float64 to prevent loss of precision.
+	case decimalType:
+		decimalValue, err := convertDecimal(value, tableInfo.ColumnLengths[column], tableInfo.ColumnScales[column])
+		if err != nil {
+			return nil, fmt.Errorf("convert to decimal: %w", err)
+		}
+
+		return decimalValue, nil
+
+	case decimalFloatType:
+		decFloatValue, err := convertDecFloat(value)
+		if err != nil {
+			return nil, fmt.Errorf("convert to decfloat: %w", err)
+		}
+
+		return decFloatValue, nil
+
+	case smallintType, integerType, bigintType:
+		intValue, err := convertInteger(value, integerBitSizes[colType])
+		if err != nil {
+			return nil, fmt.Errorf("convert to integer: %w", err)
+		}
+
+		return intValue, nil
+
+	case realType, doubleType:
+		return convertFloat(value)
+
+	default:
+		// json.Number is not a database type, pass its exact textual representation.
+		if number, ok := value.(json.Number); ok {
+			return number.String(), nil
+		}
+
+		return value, nil
+	}
+}
 
-			result[key] = []byte(valueStr)
+// columnType returns a type of the column, FOR BIT DATA columns are considered binary.
+func columnType(column string, tableInfo TableInfo) string {
+	if tableInfo.ForBitData[column] {
+		return binaryType
+	}
+
+	return tableInfo.ColumnTypes[column]
+}
+
+// toBytes returns bytes of a string or byte slice value returned by the driver.
+func toBytes(column string, value any) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	default:
+		return nil, convertValueToBytesErr(column)
+	}
+}
 
+// convertString converts a scalar value to a string.
+func convertString(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	default:
+		return "", ErrValueIsNotAString
+	}
+}
+
+// convertBoolean converts a boolean, numeric or string value to a bool.
+func convertBoolean(value any) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case []byte:
+		return convertBoolean(string(v))
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "t", "yes", "y", "on", "1":
+			return true, nil
+		case "false", "f", "no", "n", "off", "0":
+			return false, nil
 		default:
-			result[key] = value
+			return false, fmt.Errorf("%q: %w", v, ErrInvalidBoolean)
+		}
+	default:
+		intValue, err := convertInteger(value, 64)
+		if err != nil {
+			return false, fmt.Errorf("%w: %w", ErrInvalidBoolean, err)
+		}
+
+		switch intValue {
+		case 0:
+			return false, nil
+		case 1:
+			return true, nil
+		default:
+			return false, fmt.Errorf("%d: %w", intValue, ErrInvalidBoolean)
 		}
 	}
+}
 
-	return result, nil
+// convertFloat converts a numeric value to a float64.
+func convertFloat(value any) (any, error) {
+	switch v := value.(type) {
+	case json.Number:
+		floatValue, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("parse float: %w", err)
+		}
+
+		return floatValue, nil
+	case string:
+		floatValue, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse float: %w", err)
+		}
+
+		return floatValue, nil
+	default:
+		return value, nil
+	}
+}
+
+// primaryKeyColumns returns the columns of the primary key in the order of their positions in the key.
+func primaryKeyColumns(keySequences map[string]int) []string {
+	columns := make([]string, 0, len(keySequences))
+	for column := range keySequences {
+		columns = append(columns, column)
+	}
+
+	sort.Slice(columns, func(i, j int) bool {
+		return keySequences[columns[i]] < keySequences[columns[j]]
+	})
+
+	return columns
 }
 
 // GetTableInfo returns a map containing all table's columns and their database types
 // and returns primary columns names.
 func GetTableInfo(ctx context.Context, querier Querier, tableName string) (TableInfo, error) {
-	rows, err := querier.QueryContext(ctx, fmt.Sprintf(querySchemaColumnTypes, tableName))
+	rows, err := querier.QueryContext(ctx, querySchemaColumnTypes, tableName)
 	if err != nil {
 		return TableInfo{}, fmt.Errorf("query column types: %w", err)
 	}
@@ -253,24 +603,32 @@ func GetTableInfo(ctx context.Context, querier Querier, tableName string) (Table
 
 	columnTypes := make(map[string]string)
 	columnLengths := make(map[string]int)
-	primaryKeys := make([]string, 0)
+	columnScales := make(map[string]int)
+	forBitData := make(map[string]bool)
+	keySequences := make(map[string]int)
 
 	for rows.Next() {
 		var (
 			columnName, dataType string
-			length               int
+			length, scale        int
+			codePage             *int
 			keyseq               *int
 		)
-		if er := rows.Scan(&columnName, &dataType, &length, &keyseq); er != nil {
+		if er := rows.Scan(&columnName, &dataType, &length, &scale, &codePage, &keyseq); er != nil {
 			return TableInfo{}, fmt.Errorf("scan rows: %w", er)
 		}
 
 		columnTypes[columnName] = dataType
 		columnLengths[columnName] = length
+		columnScales[columnName] = scale
 
-		// check is it primary key.
-		if keyseq != nil && *keyseq == 1 {
-			primaryKeys = append(primaryKeys, columnName)
+		if codePage != nil && *codePage == codePageBitData && slices.Contains(typesForBitData, dataType) {
+			forBitData[columnName] = true
+		}
+
+		// columns of the primary key have their positions in the key.
+		if keyseq != nil {
+			keySequences[columnName] = *keyseq
 		}
 	}
 	if err := rows.Err(); err != nil {
@@ -279,20 +637,9 @@ func GetTableInfo(ctx context.Context, querier Querier, tableName string) (Table
 
 	return TableInfo{
 		ColumnTypes:   columnTypes,
-		PrimaryKeys:   primaryKeys,
+		PrimaryKeys:   primaryKeyColumns(keySequences),
 		ColumnLengths: columnLengths,
+		ColumnScales:  columnScales,
+		ForBitData:    forBitData,
 	}, nil
 }
-
-func parseTime(val string) (time.Time, error) {
-	for _, l := range layouts {
-		timeValue, err := time.Parse(l, val)
-		if err != nil {
-			continue
-		}
-
-		return timeValue, nil
-	}
-
-	return time.Time{}, fmt.Errorf("%s - %w", val, ErrInvalidTimeLayout)
-}
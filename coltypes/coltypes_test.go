@@ -15,31 +15,109 @@
 package coltypes
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
 )
 
 func Test_parseToTime(t *testing.T) {
 	t.Parallel()
 
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	tests := []struct {
-		name     string
-		strValue string
-		wantErr  bool
+		name       string
+		strValue   string
+		columnType string
+		want       time.Time
+		wantErr    bool
 	}{
 		{
-			name:     "success RFC1123",
-			strValue: "Sun, 12 Dec 2021 12:23:00 UTC",
-			wantErr:  false,
+			name:       "success RFC1123",
+			strValue:   "Sun, 12 Dec 2021 12:23:00 UTC",
+			columnType: timeStamp,
+			want:       time.Date(2021, 12, 12, 12, 23, 0, 0, time.UTC),
+		},
+		{
+			name:       "success RFC3339",
+			strValue:   "2014-11-12T11:45:26.371Z",
+			columnType: timeStamp,
+			want:       time.Date(2014, 11, 12, 11, 45, 26, 371000000, time.UTC),
+		},
+		{
+			name:       "success DB2 ISO timestamp",
+			strValue:   "2022-11-08-10.20.30.123456",
+			columnType: timeStamp,
+			want:       time.Date(2022, 11, 8, 10, 20, 30, 123456000, berlin),
+		},
+		{
+			name:       "success ISO timestamp without time zone",
+			strValue:   "2022-11-08 10:20:30",
+			columnType: timeStamp,
+			want:       time.Date(2022, 11, 8, 10, 20, 30, 0, berlin),
+		},
+		{
+			name:       "success ISO date as timestamp",
+			strValue:   "2022-11-08",
+			columnType: timeStamp,
+			want:       time.Date(2022, 11, 8, 0, 0, 0, 0, berlin),
+		},
+		{
+			name:       "success ISO date",
+			strValue:   "2022-11-08",
+			columnType: dateType,
+			want:       time.Date(2022, 11, 8, 0, 0, 0, 0, berlin),
+		},
+		{
+			name:       "success USA date",
+			strValue:   "11/08/2022",
+			columnType: dateType,
+			want:       time.Date(2022, 11, 8, 0, 0, 0, 0, berlin),
 		},
 		{
-			name:     "success RFC3339",
-			strValue: "2014-11-12T11:45:26.371Z",
-			wantErr:  false,
+			name:       "success EUR date",
+			strValue:   "08.11.2022",
+			columnType: dateType,
+			want:       time.Date(2022, 11, 8, 0, 0, 0, 0, berlin),
 		},
 		{
-			name:     "failed",
-			strValue: "test",
-			wantErr:  true,
+			name:       "success ISO time",
+			strValue:   "10.20.30",
+			columnType: timeType,
+			want:       time.Date(0, 1, 1, 10, 20, 30, 0, berlin),
+		},
+		{
+			name:       "success JIS time",
+			strValue:   "10:20:30",
+			columnType: timeType,
+			want:       time.Date(0, 1, 1, 10, 20, 30, 0, berlin),
+		},
+		{
+			name:       "success USA time",
+			strValue:   "10:20 PM",
+			columnType: timeType,
+			want:       time.Date(0, 1, 1, 22, 20, 0, 0, berlin),
+		},
+		{
+			name:       "failed USA time as timestamp",
+			strValue:   "10:20 PM",
+			columnType: timeStamp,
+			wantErr:    true,
+		},
+		{
+			name:       "failed",
+			strValue:   "test",
+			columnType: timeStamp,
+			wantErr:    true,
 		},
 	}
 
@@ -48,12 +126,324 @@ func Test_parseToTime(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			_, err := parseTime(tt.strValue)
+			got, err := parseTime(tt.strValue, tt.columnType, berlin)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
 
 				return
 			}
+
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
+
+func testTableInfo() TableInfo {
+	return TableInfo{
+		ColumnTypes: map[string]string{
+			"CL_CHAR":            charType,
+			"CL_VARCHAR":         varcharType,
+			"CL_LONG_VARCHAR":    longVarcharType,
+			"CL_CLOB":            clobType,
+			"CL_GRAPHIC":         graphicType,
+			"CL_VARGRAPHIC":      varGraphicType,
+			"CL_LONG_VARGRAPHIC": longVarGraphicType,
+			"CL_DBCLOB":          dbClobType,
+			"CL_XML":             xmlType,
+			"CL_CHAR_BIT":        charType,
+			"CL_VARCHAR_BIT":     varcharType,
+			"CL_SMALLINT":        smallintType,
+			"CL_INTEGER":         integerType,
+			"CL_BIGINT":          bigintType,
+			"CL_REAL":            realType,
+			"CL_DOUBLE":          doubleType,
+			"CL_DECIMAL":         decimalType,
+			"CL_DECFLOAT":        decimalFloatType,
+			"CL_BOOLEAN":         booleanType,
+			"CL_DATE":            dateType,
+			"CL_TIME":            timeType,
+			"CL_TIMESTAMP":       timeStamp,
+			"CL_TIMESTAMP_12":    timeStamp,
+			"CL_TIMESTAMP_TZ":    timeStampTZ,
+			"CL_BINARY":          binaryType,
+			"CL_VARBINARY":       varbinaryType,
+			"CL_BLOB":            blobType,
+			"CL_ROWID":           rowIDType,
+		},
+		ColumnLengths: map[string]int{
+			"CL_CHAR": 10, "CL_VARCHAR": 20, "CL_CHAR_BIT": 4, "CL_VARCHAR_BIT": 4, "CL_DECIMAL": 7,
+		},
+		ColumnScales: map[string]int{
+			"CL_DECIMAL": 2, "CL_TIMESTAMP": 6, "CL_TIMESTAMP_12": 12, "CL_TIMESTAMP_TZ": 6,
+		},
+		ForBitData: map[string]bool{"CL_CHAR_BIT": true, "CL_VARCHAR_BIT": true},
+	}
+}
+
+func TestTransformRow(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2022, 11, 8, 10, 20, 30, 123456000, time.UTC)
+
+	tests := []struct {
+		column string
+		value  any
+		want   any
+	}{
+		{column: "CL_CHAR", value: []byte("char"), want: "char"},
+		{column: "CL_VARCHAR", value: []byte("varchar"), want: "varchar"},
+		{column: "CL_LONG_VARCHAR", value: []byte("long varchar"), want: "long varchar"},
+		{column: "CL_CLOB", value: []byte("clob"), want: "clob"},
+		{column: "CL_GRAPHIC", value: []byte("graphic"), want: "graphic"},
+		{column: "CL_VARGRAPHIC", value: []byte("vargraphic"), want: "vargraphic"},
+		{column: "CL_LONG_VARGRAPHIC", value: []byte("long vargraphic"), want: "long vargraphic"},
+		{column: "CL_DBCLOB", value: []byte("dbclob"), want: "dbclob"},
+		{column: "CL_XML", value: []byte("<a>1</a>"), want: "<a>1</a>"},
+		{column: "CL_CHAR_BIT", value: []byte{0x00, 0xff}, want: []byte{0x00, 0xff}},
+		{column: "CL_VARCHAR_BIT", value: []byte{0x01}, want: []byte{0x01}},
+		{column: "CL_SMALLINT", value: int32(1), want: int32(1)},
+		{column: "CL_INTEGER", value: int32(2), want: int32(2)},
+		{column: "CL_BIGINT", value: int64(9007199254740993), want: int64(9007199254740993)},
+		{column: "CL_REAL", value: float64(1.5), want: float64(1.5)},
+		{column: "CL_DOUBLE", value: float64(2.5), want: float64(2.5)},
+		{column: "CL_DECIMAL", value: []byte("12.3"), want: "12.30"},
+		{column: "CL_DECFLOAT", value: []byte("1.000000000000000000000000000000001"),
+			want: "1.000000000000000000000000000000001"},
+		{column: "CL_BOOLEAN", value: true, want: true},
+		{column: "CL_DATE", value: ts, want: ts},
+		{column: "CL_TIME", value: ts, want: ts},
+		{column: "CL_TIMESTAMP", value: ts, want: ts},
+		{column: "CL_TIMESTAMP_12", value: []byte("2022-11-08-10.20.30.123456789012"),
+			want: "2022-11-08T10:20:30.123456789012"},
+		{column: "CL_TIMESTAMP_TZ", value: []byte("2022-11-08-10.20.30.123456+02:00"),
+			want: time.Date(2022, 11, 8, 10, 20, 30, 123456000, time.FixedZone("", 2*60*60))},
+		{column: "CL_BINARY", value: []byte{0x01, 0x02}, want: []byte{0x01, 0x02}},
+		{column: "CL_VARBINARY", value: []byte{0x03}, want: []byte{0x03}},
+		{column: "CL_BLOB", value: []byte{0x04}, want: []byte{0x04}},
+		{column: "CL_ROWID", value: []byte{0x05}, want: []byte{0x05}},
+		{column: "UNKNOWN", value: "value", want: "value"},
+		{column: "CL_VARCHAR", value: nil, want: nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.column, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			got, err := TransformRow(context.Background(), map[string]any{tt.column: tt.value},
+				testTableInfo(), TransformOptions{DecimalFormat: DecimalFormatString, TimeFormat: TimeFormatTyped,
+					Location: time.UTC})
+			is.NoErr(err)
+
+			if want, ok := tt.want.(time.Time); ok {
+				is.True(want.Equal(got[tt.column].(time.Time)))
+
+				return
+			}
+
+			is.Equal(got[tt.column], tt.want)
+		})
+	}
+}
+
+func TestConvertStructureData(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2022, 11, 8, 10, 20, 30, 123456789, time.FixedZone("", -5*60*60))
+
+	tests := []struct {
+		column  string
+		value   any
+		want    any
+		wantErr bool
+	}{
+		{column: "CL_CHAR", value: "char", want: "char"},
+		{column: "CL_VARCHAR", value: json.Number("10"), want: "10"},
+		{column: "CL_LONG_VARCHAR", value: true, want: "true"},
+		{column: "CL_CLOB", value: []byte("clob"), want: "clob"},
+		{column: "CL_GRAPHIC", value: "graphic", want: "graphic"},
+		{column: "CL_VARGRAPHIC", value: "vargraphic", want: "vargraphic"},
+		{column: "CL_LONG_VARGRAPHIC", value: "long vargraphic", want: "long vargraphic"},
+		{column: "CL_DBCLOB", value: "dbclob", want: "dbclob"},
+		{column: "CL_XML", value: "<a>1</a>", want: "<a>1</a>"},
+		{column: "CL_CHAR_BIT", value: []byte{0x00, 0xff}, want: []byte{0x00, 0xff}},
+		{column: "CL_VARCHAR_BIT", value: "ab", want: []byte("ab")},
+		{column: "CL_SMALLINT", value: json.Number("7"), want: int64(7)},
+		{column: "CL_SMALLINT", value: json.Number("70000"), wantErr: true},
+		{column: "CL_INTEGER", value: float64(8), want: int64(8)},
+		{column: "CL_BIGINT", value: json.Number("9007199254740993"), want: int64(9007199254740993)},
+		{column: "CL_REAL", value: json.Number("1.5"), want: float64(1.5)},
+		{column: "CL_DOUBLE", value: float64(2.5), want: float64(2.5)},
+		{column: "CL_DECIMAL", value: json.Number("12.340"), want: "12.34"},
+		{column: "CL_DECFLOAT", value: int64(3), want: "3"},
+		{column: "CL_BOOLEAN", value: "yes", want: true},
+		{column: "CL_BOOLEAN", value: json.Number("0"), want: false},
+		{column: "CL_BOOLEAN", value: "maybe", wantErr: true},
+		{column: "CL_DATE", value: "2022-11-08T00:00:00Z", want: time.Date(2022, 11, 8, 0, 0, 0, 0, time.UTC)},
+		{column: "CL_TIME", value: ts, want: ts},
+		{column: "CL_TIMESTAMP", value: "2022-11-08T10:20:30.5Z", want: time.Date(2022, 11, 8, 10, 20, 30, 5e8, time.UTC)},
+		{column: "CL_TIMESTAMP_12", value: "2022-11-08T10:20:30.123456789012", want: "2022-11-08-10.20.30.123456789012"},
+		{column: "CL_TIMESTAMP_12", value: ts, want: "2022-11-08-15.20.30.123456789000"},
+		{column: "CL_TIMESTAMP_TZ", value: ts, want: "2022-11-08-10.20.30.123456-05:00"},
+		{column: "CL_TIMESTAMP_TZ", value: "2022-11-08T10:20:30Z", want: "2022-11-08-10.20.30.000000+00:00"},
+		{column: "CL_BINARY", value: []byte{0x01}, want: []byte{0x01}},
+		{column: "CL_VARBINARY", value: "a", want: []byte("a")},
+		{column: "CL_BLOB", value: []byte{0x02}, want: []byte{0x02}},
+		{column: "CL_ROWID", value: []byte{0x03}, want: []byte{0x03}},
+		{column: "CL_BINARY", value: json.Number("1"), wantErr: true},
+		{column: "UNKNOWN", value: json.Number("1.10"), want: "1.10"},
+		{column: "CL_VARCHAR", value: map[string]any{"a": json.Number("1")}, want: `{"a":1}`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.column, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			// payload keys are matched to columns case-insensitively, the result is keyed by the columns.
+			key := strings.ToLower(tt.column)
+			column := testTableInfo().Column(key)
+
+			got, err := ConvertStructureData(context.Background(), testTableInfo(), opencdc.StructuredData{key: tt.value},
+				ConvertOptions{Location: time.UTC})
+			if tt.wantErr {
+				is.True(err != nil)
+
+				return
+			}
+
+			is.NoErr(err)
+
+			if want, ok := tt.want.(time.Time); ok {
+				is.True(want.Equal(got[column].(time.Time)))
+
+				return
+			}
+
+			is.Equal(got[column], tt.want)
+		})
+	}
+}
+
+func TestTableInfo_Column(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	tableInfo := TableInfo{ColumnTypes: map[string]string{
+		"ID":       integerType,
+		"userId":   varcharType,
+		"USERID":   varcharType,
+		"Nickname": varcharType,
+	}}
+
+	is.Equal(tableInfo.Column("ID"), "ID")
+	is.Equal(tableInfo.Column("id"), "ID")
+	is.Equal(tableInfo.Column("userId"), "userId")
+	is.Equal(tableInfo.Column("USERID"), "USERID")
+	is.Equal(tableInfo.Column("UserId"), "USERID")
+	is.Equal(tableInfo.Column("nickname"), "Nickname")
+	is.Equal(tableInfo.Column("email"), "email")
+}
+
+func Test_primaryKeyColumns(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	is.Equal(primaryKeyColumns(map[string]int{}), []string{})
+	is.Equal(primaryKeyColumns(map[string]int{"ID": 1}), []string{"ID"})
+	is.Equal(primaryKeyColumns(map[string]int{"LINE": 2, "TENANT": 3, "ORDER_ID": 1}),
+		[]string{"ORDER_ID", "LINE", "TENANT"})
+}
+
+func TestConvertStructureData_duplicateColumn(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	_, err := ConvertStructureData(context.Background(), testTableInfo(),
+		opencdc.StructuredData{"cl_integer": int64(1), "CL_INTEGER": int64(2)}, ConvertOptions{})
+	is.True(errors.Is(err, ErrDuplicateColumn))
+}
+
+func TestTableInfo_GetCreateColumnStr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		tableInfo TableInfo
+		want      string
+	}{
+		{
+			name: "decimal",
+			tableInfo: TableInfo{
+				ColumnTypes:   map[string]string{"AMOUNT": decimalType},
+				ColumnLengths: map[string]int{"AMOUNT": 10},
+				ColumnScales:  map[string]int{"AMOUNT": 2},
+			},
+			want: `"AMOUNT" DECIMAL(10,2)`,
+		},
+		{
+			name: "timestamp",
+			tableInfo: TableInfo{
+				ColumnTypes:  map[string]string{"TS": timeStamp},
+				ColumnScales: map[string]int{"TS": 12},
+			},
+			want: `"TS" TIMESTAMP(12)`,
+		},
+		{
+			name: "timestamp with time zone",
+			tableInfo: TableInfo{
+				ColumnTypes:  map[string]string{"TS": timeStampTZ},
+				ColumnScales: map[string]int{"TS": 6},
+			},
+			want: `"TS" TIMESTAMP(6) WITH TIME ZONE`,
+		},
+		{
+			name: "for bit data",
+			tableInfo: TableInfo{
+				ColumnTypes:   map[string]string{"FLAGS": varcharType},
+				ColumnLengths: map[string]int{"FLAGS": 8},
+				ForBitData:    map[string]bool{"FLAGS": true},
+			},
+			want: `"FLAGS" VARCHAR(8) FOR BIT DATA`,
+		},
+		{
+			name: "varchar",
+			tableInfo: TableInfo{
+				ColumnTypes:   map[string]string{"NAME": varcharType},
+				ColumnLengths: map[string]int{"NAME": 40},
+			},
+			want: `"NAME" VARCHAR(40)`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			is.Equal(tt.tableInfo.GetCreateColumnStr(), tt.want)
+		})
+	}
+}
+
+func TestTableInfo_SelectColumns(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	tableInfo := TableInfo{
+		ColumnTypes: map[string]string{
+			"ID": integerType, "TS": timeStamp, "TS_12": timeStamp, "TS_TZ": timeStampTZ,
+		},
+		ColumnScales: map[string]int{"TS": 6, "TS_12": 12, "TS_TZ": 6},
+	}
+
+	is.Equal(tableInfo.SelectColumns(nil, LOBPolicies{}),
+		[]string{`"ID"`, `"TS"`, `VARCHAR("TS_12") AS "TS_12"`, `VARCHAR("TS_TZ") AS "TS_TZ"`})
+	is.Equal(tableInfo.SelectColumns([]string{"TS_12", "ID"}, LOBPolicies{}),
+		[]string{`VARCHAR("TS_12") AS "TS_12"`, `"ID"`})
+}
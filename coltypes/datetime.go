@@ -0,0 +1,320 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coltypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// TimeFormat is a representation of DATE, TIME and TIMESTAMP values in the source records.
+type TimeFormat string
+
+const (
+	// TimeFormatTyped keeps DATE, TIME and TIMESTAMP values as time values.
+	TimeFormatTyped TimeFormat = "typed"
+	// TimeFormatRFC3339 represents DATE values as "2006-01-02", TIME values as "15:04:05"
+	// and TIMESTAMP values as RFC 3339 strings with the session time zone offset.
+	TimeFormatRFC3339 TimeFormat = "rfc3339"
+	// TimeFormatEpochMillis represents DATE and TIMESTAMP values as milliseconds since the Unix epoch,
+	// and TIME values as milliseconds since midnight.
+	TimeFormatEpochMillis TimeFormat = "epochMillis"
+)
+
+const (
+	// db2TimestampLayout is a layout of DB2 timestamp strings without the fractional seconds.
+	db2TimestampLayout = "2006-01-02-15.04.05"
+	// isoTimestampLayout is a layout of ISO 8601 timestamp strings without the fractional seconds.
+	isoTimestampLayout = "2006-01-02T15:04:05"
+	// offsetLayout is a layout of time zone offsets.
+	offsetLayout = "-07:00"
+)
+
+var (
+	// timestampLayouts are layouts of timestamp strings: RFC 3339, DB2 ISO, ISO 8601 without time zone,
+	// and other common formats.
+	timestampLayouts = []string{
+		time.RFC3339Nano,
+		"2006-01-02-15.04.05.999999999",
+		"2006-01-02 15.04.05.999999999",
+		"2006-01-02 15:04:05.999999999",
+		"2006-01-02T15:04:05.999999999",
+		time.RFC1123Z, time.RFC1123, time.RFC850, time.RFC822Z, time.RFC822,
+		time.ANSIC, time.UnixDate, time.RubyDate, time.Layout,
+	}
+
+	// dateLayouts are layouts of DB2 date strings: ISO and JIS, USA, EUR.
+	dateLayouts = []string{"2006-01-02", "01/02/2006", "02.01.2006"}
+
+	// timeLayouts are layouts of DB2 time strings: ISO and EUR, JIS, USA.
+	timeLayouts = []string{"15.04.05", "15:04:05", "03:04 PM", "3:04 PM"}
+)
+
+// timestampRegexp matches DB2 (2006-01-02-15.04.05.000000000000) and ISO 8601 (2006-01-02T15:04:05.000000000000Z)
+// timestamp strings with up to picoseconds precision and an optional time zone offset.
+var timestampRegexp = regexp.MustCompile(
+	`^(\d{4}-\d{2}-\d{2})[-T ](\d{2})[.:](\d{2})[.:](\d{2})(?:[.,](\d{1,12}))?\s*(Z|[+-]\d{2}:?\d{2})?$`,
+)
+
+// timestamp is a timestamp with up to picoseconds precision.
+type timestamp struct {
+	// wall - date and time without the fractional seconds.
+	wall time.Time
+	// fraction - digits of the fractional seconds.
+	fraction string
+	// offset - time zone offset in the "-07:00" format, empty for timestamps without time zone.
+	offset string
+}
+
+// parseTimestamp parses a DB2 or ISO 8601 timestamp string.
+func parseTimestamp(s string) (timestamp, error) {
+	matches := timestampRegexp.FindStringSubmatch(strings.TrimSpace(s))
+	if matches == nil {
+		return timestamp{}, fmt.Errorf("%q: %w", s, ErrInvalidTimestamp)
+	}
+
+	wall, err := time.Parse(isoTimestampLayout,
+		fmt.Sprintf("%sT%s:%s:%s", matches[1], matches[2], matches[3], matches[4]))
+	if err != nil {
+		return timestamp{}, fmt.Errorf("%q: %w", s, ErrInvalidTimestamp)
+	}
+
+	offset := matches[6]
+	switch {
+	case offset == "Z":
+		offset = "+00:00"
+	case len(offset) == len("+0000"):
+		offset = offset[:3] + ":" + offset[3:]
+	}
+
+	return timestamp{wall: wall, fraction: matches[5], offset: offset}, nil
+}
+
+// timestampFromTime returns a timestamp from the time.Time value.
+func timestampFromTime(t time.Time) timestamp {
+	return timestamp{
+		wall:     t.Truncate(time.Second),
+		fraction: fmt.Sprintf("%09d", t.Nanosecond()),
+		offset:   t.Format(offsetLayout),
+	}
+}
+
+// withScale returns the timestamp with the fractional seconds padded or truncated to the scale.
+func (t timestamp) withScale(scale int) timestamp {
+	if len(t.fraction) < scale {
+		t.fraction += strings.Repeat("0", scale-len(t.fraction))
+	}
+
+	t.fraction = t.fraction[:scale]
+
+	return t
+}
+
+// db2String returns the timestamp in the DB2 format, e.g. 2006-01-02-15.04.05.000000000000+07:00.
+func (t timestamp) db2String(withOffset bool) string {
+	s := t.wall.Format(db2TimestampLayout)
+	if t.fraction != "" {
+		s += "." + t.fraction
+	}
+
+	if withOffset {
+		s += t.offset
+	}
+
+	return s
+}
+
+// isoString returns the timestamp in the ISO 8601 format, e.g. 2006-01-02T15:04:05.000000000000+07:00.
+func (t timestamp) isoString() string {
+	s := t.wall.Format(isoTimestampLayout)
+	if t.fraction != "" {
+		s += "." + t.fraction
+	}
+
+	return s + t.offset
+}
+
+// transformTimestamp converts a timestamp string, selected instead of a timestamp that doesn't fit time.Time,
+// to the representation defined by the options. Typed values are returned as a time.Time if it is possible
+// without loss of precision, or as an ISO 8601 string otherwise.
+func transformTimestamp(value string, scale int, opts TransformOptions) (any, error) {
+	ts, err := parseTimestamp(value)
+	if err != nil {
+		return nil, err
+	}
+
+	ts = ts.withScale(scale)
+
+	if ts.offset == "" {
+		if opts.TimeFormat != TimeFormatRFC3339 && opts.TimeFormat != TimeFormatEpochMillis {
+			return ts.isoString(), nil
+		}
+
+		// timestamps without time zone are in the session time zone.
+		ts.offset = time.Date(ts.wall.Year(), ts.wall.Month(), ts.wall.Day(), ts.wall.Hour(), ts.wall.Minute(),
+			ts.wall.Second(), 0, location(opts.Location)).Format(offsetLayout)
+	}
+
+	if opts.TimeFormat == TimeFormatRFC3339 ||
+		(opts.TimeFormat != TimeFormatEpochMillis && scale > maxNanosecondsScale) {
+		return ts.isoString(), nil
+	}
+
+	timeValue, err := time.Parse(time.RFC3339Nano, ts.withScale(maxNanosecondsScale).isoString())
+	if err != nil {
+		return nil, fmt.Errorf("%q: %w", value, ErrInvalidTimestamp)
+	}
+
+	return formatTime(timeStamp, timeValue, opts.TimeFormat), nil
+}
+
+// convertTimestampString converts a time.Time, epoch milliseconds or a timestamp string to a DB2 timestamp string
+// with the provided precision, which is used for timestamps that don't fit time.Time
+// and for timestamps with time zone.
+func convertTimestampString(value any, scale int, withOffset bool, loc *time.Location) (string, error) {
+	if v, ok := value.(string); ok {
+		ts, err := parseTimestamp(v)
+		if err == nil {
+			// timestamps without time zone are stored in the session time zone.
+			if ts.offset != "" && !withOffset {
+				wall, er := time.Parse(isoTimestampLayout+offsetLayout, ts.wall.Format(isoTimestampLayout)+ts.offset)
+				if er != nil {
+					return "", fmt.Errorf("%q: %w", v, ErrInvalidTimestamp)
+				}
+
+				ts.wall = wall.In(location(loc))
+			}
+
+			return ts.withScale(scale).db2String(withOffset), nil
+		}
+	}
+
+	// time values keep their own offset in timestamps with time zone.
+	if v, ok := value.(time.Time); ok && withOffset {
+		return timestampFromTime(v).withScale(scale).db2String(withOffset), nil
+	}
+
+	timeValue, err := convertTime(value, timeStamp, loc)
+	if err != nil {
+		return "", err
+	}
+
+	return timestampFromTime(timeValue).withScale(scale).db2String(withOffset), nil
+}
+
+// convertTime converts a time.Time, epoch milliseconds or a date/time string to a time.Time
+// in the session time zone.
+func convertTime(value any, columnType string, loc *time.Location) (time.Time, error) {
+	loc = location(loc)
+
+	switch v := value.(type) {
+	case time.Time:
+		return v.In(loc), nil
+
+	case string:
+		timeValue, err := parseTime(v, columnType, loc)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("convert value to time.Time: %w", err)
+		}
+
+		return timeValue, nil
+
+	case json.Number, float64, float32, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		millis, err := convertInteger(value, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("convert epoch milliseconds: %w", err)
+		}
+
+		return time.UnixMilli(millis).In(loc), nil
+
+	default:
+		return time.Time{}, ErrValueIsNotAString
+	}
+}
+
+// parseTime parses a date/time string, trying layouts suitable for the column type.
+// Values without time zone are interpreted in the provided location.
+func parseTime(val string, columnType string, loc *time.Location) (time.Time, error) {
+	var layouts []string
+
+	switch columnType {
+	case dateType:
+		layouts = append(append(layouts, dateLayouts...), timestampLayouts...)
+	case timeType:
+		layouts = append(append(layouts, timeLayouts...), timestampLayouts...)
+	default:
+		layouts = append(append(layouts, timestampLayouts...), dateLayouts...)
+	}
+
+	val = strings.TrimSpace(val)
+
+	for _, l := range layouts {
+		timeValue, err := time.ParseInLocation(l, val, loc)
+		if err != nil {
+			continue
+		}
+
+		return timeValue.In(loc), nil
+	}
+
+	return time.Time{}, fmt.Errorf("%s - %w", val, ErrInvalidTimeLayout)
+}
+
+// formatTime returns the representation of a DATE, TIME or TIMESTAMP value defined by the time format.
+func formatTime(columnType string, t time.Time, format TimeFormat) any {
+	switch format {
+	case TimeFormatRFC3339:
+		switch columnType {
+		case dateType:
+			return t.Format(time.DateOnly)
+		case timeType:
+			return t.Format(time.TimeOnly)
+		default:
+			return t.Format(time.RFC3339Nano)
+		}
+
+	case TimeFormatEpochMillis:
+		switch columnType {
+		case dateType:
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).UnixMilli()
+		case timeType:
+			return (time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+				time.Duration(t.Second())*time.Second).Milliseconds()
+		default:
+			return t.UnixMilli()
+		}
+
+	default:
+		return t
+	}
+}
+
+// inLocation returns the time with the same wall clock in the provided location.
+// The driver returns values without time zone in the local time zone of the connector.
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), location(loc))
+}
+
+// location returns the provided location, or the local time zone if it is not set.
+func location(loc *time.Location) *time.Location {
+	if loc == nil {
+		return time.Local
+	}
+
+	return loc
+}
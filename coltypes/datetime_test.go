@@ -0,0 +1,166 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coltypes
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestTransformRow_timeFormats(t *testing.T) {
+	t.Parallel()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tableInfo := TableInfo{
+		ColumnTypes: map[string]string{
+			"CL_DATE": dateType, "CL_TIME": timeType, "CL_TIMESTAMP": timeStamp, "CL_TIMESTAMP_12": timeStamp,
+		},
+		ColumnScales: map[string]int{"CL_TIMESTAMP": 6, "CL_TIMESTAMP_12": 12},
+	}
+
+	// the driver returns values without time zone in the local time zone.
+	row := map[string]any{
+		"CL_DATE":         time.Date(2022, 11, 8, 0, 0, 0, 0, time.Local),
+		"CL_TIME":         time.Date(1, 1, 1, 10, 20, 30, 0, time.Local),
+		"CL_TIMESTAMP":    time.Date(2022, 11, 8, 10, 20, 30, 123456000, time.Local),
+		"CL_TIMESTAMP_12": []byte("2022-11-08-10.20.30.123456789012"),
+	}
+
+	tests := []struct {
+		format TimeFormat
+		want   map[string]any
+	}{
+		{
+			format: TimeFormatRFC3339,
+			want: map[string]any{
+				"CL_DATE":         "2022-11-08",
+				"CL_TIME":         "10:20:30",
+				"CL_TIMESTAMP":    "2022-11-08T10:20:30.123456+09:00",
+				"CL_TIMESTAMP_12": "2022-11-08T10:20:30.123456789012+09:00",
+			},
+		},
+		{
+			format: TimeFormatEpochMillis,
+			want: map[string]any{
+				"CL_DATE":         time.Date(2022, 11, 8, 0, 0, 0, 0, tokyo).UnixMilli(),
+				"CL_TIME":         int64((10*60*60 + 20*60 + 30) * 1000),
+				"CL_TIMESTAMP":    time.Date(2022, 11, 8, 10, 20, 30, 123456000, tokyo).UnixMilli(),
+				"CL_TIMESTAMP_12": time.Date(2022, 11, 8, 10, 20, 30, 123456000, tokyo).UnixMilli(),
+			},
+		},
+		{
+			format: TimeFormatTyped,
+			want: map[string]any{
+				"CL_DATE":         time.Date(2022, 11, 8, 0, 0, 0, 0, tokyo),
+				"CL_TIME":         time.Date(1, 1, 1, 10, 20, 30, 0, tokyo),
+				"CL_TIMESTAMP":    time.Date(2022, 11, 8, 10, 20, 30, 123456000, tokyo),
+				"CL_TIMESTAMP_12": "2022-11-08T10:20:30.123456789012",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(string(tt.format), func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			got, err := TransformRow(context.Background(), row, tableInfo,
+				TransformOptions{TimeFormat: tt.format, Location: tokyo})
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+func TestConvertStructureData_location(t *testing.T) {
+	t.Parallel()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tableInfo := TableInfo{
+		ColumnTypes: map[string]string{
+			"CL_TIMESTAMP": timeStamp, "CL_TIMESTAMP_12": timeStamp,
+		},
+		ColumnScales: map[string]int{"CL_TIMESTAMP": 6, "CL_TIMESTAMP_12": 12},
+	}
+
+	tests := []struct {
+		name  string
+		value any
+		want  any
+	}{
+		{
+			name:  "time with time zone",
+			value: time.Date(2022, 11, 8, 1, 20, 30, 0, time.UTC),
+			want:  time.Date(2022, 11, 8, 10, 20, 30, 0, tokyo),
+		},
+		{
+			name:  "string with time zone",
+			value: "2022-11-08T01:20:30Z",
+			want:  time.Date(2022, 11, 8, 10, 20, 30, 0, tokyo),
+		},
+		{
+			name:  "string without time zone",
+			value: "2022-11-08-10.20.30",
+			want:  time.Date(2022, 11, 8, 10, 20, 30, 0, tokyo),
+		},
+		{
+			name:  "epoch milliseconds",
+			value: json.Number("1667870430000"),
+			want:  time.Date(2022, 11, 8, 10, 20, 30, 0, tokyo),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			got, err := ConvertStructureData(context.Background(), tableInfo,
+				opencdc.StructuredData{"cl_timestamp": tt.value}, ConvertOptions{Location: tokyo})
+			is.NoErr(err)
+
+			gotTime, ok := got["CL_TIMESTAMP"].(time.Time)
+			is.True(ok)
+			is.Equal(gotTime.Location(), tokyo)
+			is.True(gotTime.Equal(tt.want.(time.Time)))
+		})
+	}
+
+	t.Run("picoseconds with time zone", func(t *testing.T) {
+		t.Parallel()
+		is := is.New(t)
+
+		got, err := ConvertStructureData(context.Background(), tableInfo,
+			opencdc.StructuredData{"cl_timestamp_12": "2022-11-08T01:20:30.123456789012Z"},
+			ConvertOptions{Location: tokyo})
+		is.NoErr(err)
+		is.Equal(got["CL_TIMESTAMP_12"], "2022-11-08-10.20.30.123456789012")
+	})
+}
@@ -0,0 +1,341 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coltypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DecimalFormat is a representation of DECIMAL values in the source records.
+type DecimalFormat string
+
+const (
+	// DecimalFormatString represents a DECIMAL value as a string, e.g. "123.45".
+	DecimalFormatString DecimalFormat = "string"
+	// DecimalFormatScaled represents a DECIMAL value as an unscaled integer
+	// according to the column scale, e.g. 12345 for 123.45 in a DECIMAL(5,2) column.
+	DecimalFormatScaled DecimalFormat = "scaled"
+	// DecimalFormatAvro represents a DECIMAL value as the big-endian two's-complement
+	// bytes of the unscaled integer, as defined by the Avro decimal logical type.
+	DecimalFormatAvro DecimalFormat = "avro"
+)
+
+// special DECFLOAT values.
+var decFloatSpecialValues = map[string]string{
+	"nan":       "NaN",
+	"snan":      "sNaN",
+	"-nan":      "-NaN",
+	"-snan":     "-sNaN",
+	"infinity":  "Infinity",
+	"+infinity": "Infinity",
+	"inf":       "Infinity",
+	"+inf":      "Infinity",
+	"-infinity": "-Infinity",
+	"-inf":      "-Infinity",
+}
+
+// maxDecimalExponent is the maximum absolute exponent of parsed decimal strings. It exceeds the exponent range
+// of DECFLOAT(34), which is the widest DB2 decimal type, and prevents rescaling by huge powers of ten.
+const maxDecimalExponent = 6200
+
+// decimal is an exact decimal number, represented by an unscaled integer and a scale,
+// where the value equals unscaled * 10^-scale.
+type decimal struct {
+	unscaled *big.Int
+	scale    int
+}
+
+// parseDecimal parses a decimal string, with an optional sign, fraction and exponent, without loss of precision.
+// Exponents beyond maxDecimalExponent are rejected.
+func parseDecimal(s string) (decimal, error) {
+	s = strings.TrimSpace(s)
+
+	mantissa, exponent := s, 0
+	if idx := strings.IndexAny(s, "eE"); idx >= 0 {
+		exp, err := strconv.Atoi(s[idx+1:])
+		if err != nil || exp > maxDecimalExponent || exp < -maxDecimalExponent {
+			return decimal{}, fmt.Errorf("%q: %w", s, ErrInvalidDecimal)
+		}
+
+		mantissa, exponent = s[:idx], exp
+	}
+
+	negative := strings.HasPrefix(mantissa, "-")
+	mantissa = strings.TrimPrefix(strings.TrimPrefix(mantissa, "-"), "+")
+
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+
+	digits := intPart + fracPart
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return decimal{}, fmt.Errorf("%q: %w", s, ErrInvalidDecimal)
+	}
+
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return decimal{}, fmt.Errorf("%q: %w", s, ErrInvalidDecimal)
+	}
+
+	if negative {
+		unscaled.Neg(unscaled)
+	}
+
+	d := decimal{unscaled: unscaled, scale: len(fracPart) - exponent}
+	if d.scale < 0 {
+		d = d.rescale(0)
+	}
+
+	return d, nil
+}
+
+// decimalFromInt returns a decimal with the zero scale.
+func decimalFromInt(v *big.Int) decimal {
+	return decimal{unscaled: v, scale: 0}
+}
+
+// decimalFromAvroBytes returns a decimal from the big-endian two's-complement representation of the unscaled value.
+func decimalFromAvroBytes(b []byte, scale int) decimal {
+	unscaled := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+	}
+
+	return decimal{unscaled: unscaled, scale: scale}
+}
+
+// rescale returns the decimal with the provided scale.
+// Excess fractional digits are rounded half away from zero.
+func (d decimal) rescale(scale int) decimal {
+	switch {
+	case scale == d.scale:
+		return d
+
+	case scale > d.scale:
+		factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale-d.scale)), nil)
+
+		return decimal{unscaled: new(big.Int).Mul(d.unscaled, factor), scale: scale}
+
+	default:
+		factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.scale-scale)), nil)
+
+		quo, rem := new(big.Int).QuoRem(d.unscaled, factor, new(big.Int))
+
+		// round half away from zero.
+		if rem.Mul(rem.Abs(rem), big.NewInt(2)).Cmp(factor) >= 0 {
+			quo.Add(quo, big.NewInt(int64(d.unscaled.Sign())))
+		}
+
+		return decimal{unscaled: quo, scale: scale}
+	}
+}
+
+// precision returns the number of digits of the unscaled value.
+func (d decimal) precision() int {
+	return len(new(big.Int).Abs(d.unscaled).String())
+}
+
+// String returns the plain (non-exponential) string representation of the decimal.
+func (d decimal) String() string {
+	digits := new(big.Int).Abs(d.unscaled).String()
+
+	sign := ""
+	if d.unscaled.Sign() < 0 {
+		sign = "-"
+	}
+
+	if d.scale <= 0 {
+		return sign + digits + strings.Repeat("0", -d.scale)
+	}
+
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+}
+
+// scaledValue returns the unscaled value as an int64 if it fits, or as a *big.Int otherwise.
+// Both are marshaled into exact JSON numbers.
+func (d decimal) scaledValue() any {
+	if d.unscaled.IsInt64() {
+		return d.unscaled.Int64()
+	}
+
+	return d.unscaled
+}
+
+// avroBytes returns the big-endian two's-complement representation of the unscaled value.
+func (d decimal) avroBytes() []byte {
+	if d.unscaled.Sign() >= 0 {
+		b := d.unscaled.Bytes()
+		if len(b) == 0 || b[0]&0x80 != 0 {
+			b = append([]byte{0}, b...)
+		}
+
+		return b
+	}
+
+	size := d.unscaled.BitLen()/8 + 1
+
+	b := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), uint(size*8)), d.unscaled).Bytes()
+
+	// trim redundant sign bytes.
+	for len(b) > 1 && b[0] == 0xff && b[1]&0x80 != 0 {
+		b = b[1:]
+	}
+
+	return b
+}
+
+// formatDecimal converts a DECIMAL value returned by the driver to the provided representation.
+func formatDecimal(value []byte, scale int, format DecimalFormat) (any, error) {
+	d, err := parseDecimal(string(value))
+	if err != nil {
+		return nil, err
+	}
+
+	d = d.rescale(scale)
+
+	switch format {
+	case DecimalFormatScaled:
+		return d.scaledValue(), nil
+	case DecimalFormatAvro:
+		return d.avroBytes(), nil
+	default:
+		return d.String(), nil
+	}
+}
+
+// toDecimal converts a value of any numeric form to a decimal.
+func toDecimal(value any, scale int) (decimal, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return parseDecimal(v.String())
+	case string:
+		return parseDecimal(v)
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return decimal{}, fmt.Errorf("%v: %w", v, ErrInvalidDecimal)
+		}
+
+		return parseDecimal(strconv.FormatFloat(v, 'f', -1, 64))
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return decimal{}, fmt.Errorf("%v: %w", v, ErrInvalidDecimal)
+		}
+
+		return parseDecimal(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	case []byte:
+		return decimalFromAvroBytes(v, scale), nil
+	case *big.Int:
+		return decimalFromInt(new(big.Int).Set(v)), nil
+	case int:
+		return decimalFromInt(big.NewInt(int64(v))), nil
+	case int8:
+		return decimalFromInt(big.NewInt(int64(v))), nil
+	case int16:
+		return decimalFromInt(big.NewInt(int64(v))), nil
+	case int32:
+		return decimalFromInt(big.NewInt(int64(v))), nil
+	case int64:
+		return decimalFromInt(big.NewInt(v)), nil
+	case uint:
+		return decimalFromInt(new(big.Int).SetUint64(uint64(v))), nil
+	case uint8:
+		return decimalFromInt(new(big.Int).SetUint64(uint64(v))), nil
+	case uint16:
+		return decimalFromInt(new(big.Int).SetUint64(uint64(v))), nil
+	case uint32:
+		return decimalFromInt(new(big.Int).SetUint64(uint64(v))), nil
+	case uint64:
+		return decimalFromInt(new(big.Int).SetUint64(v)), nil
+	default:
+		return decimal{}, fmt.Errorf("%T: %w", value, ErrInvalidDecimal)
+	}
+}
+
+// convertDecimal converts a value of any numeric form to a DECIMAL string
+// with the provided precision and scale, without loss of non-zero fractional digits.
+func convertDecimal(value any, precision, scale int) (string, error) {
+	d, err := toDecimal(value, scale)
+	if err != nil {
+		return "", err
+	}
+
+	// fractional digits which the scale would drop are rejected the same way as fractions of integers.
+	if scale < d.scale && d.rescale(scale).rescale(d.scale).unscaled.Cmp(d.unscaled) != 0 {
+		return "", fmt.Errorf("%s does not fit DECIMAL(%d,%d): %w", d, precision, scale, ErrDecimalScale)
+	}
+
+	d = d.rescale(scale)
+
+	if precision > 0 && d.precision() > precision {
+		return "", fmt.Errorf("%s does not fit DECIMAL(%d,%d): %w", d, precision, scale, ErrDecimalOverflow)
+	}
+
+	return d.String(), nil
+}
+
+// convertDecFloat converts a value of any numeric form to a DECFLOAT string without loss of precision.
+func convertDecFloat(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		if special, ok := decFloatSpecialValues[strings.ToLower(strings.TrimSpace(v))]; ok {
+			return special, nil
+		}
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return decFloatSpecialValues["nan"], nil
+		case math.IsInf(v, 1):
+			return decFloatSpecialValues["infinity"], nil
+		case math.IsInf(v, -1):
+			return decFloatSpecialValues["-infinity"], nil
+		}
+	}
+
+	d, err := toDecimal(value, 0)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrConvertDecFloat, err)
+	}
+
+	return d.String(), nil
+}
+
+// convertInteger converts a value of any numeric form to an int64
+// within the range of the provided integer type without loss of precision.
+func convertInteger(value any, bitSize int) (int64, error) {
+	d, err := toDecimal(value, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	if d.rescale(0).rescale(d.scale).unscaled.Cmp(d.unscaled) != 0 {
+		return 0, fmt.Errorf("%s: %w", d, ErrNotAnInteger)
+	}
+
+	d = d.rescale(0)
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bitSize-1))
+	if d.unscaled.Cmp(limit) >= 0 || d.unscaled.Cmp(limit.Neg(limit)) < 0 {
+		return 0, fmt.Errorf("%s does not fit %d-bit integer: %w", d, bitSize, ErrIntegerOverflow)
+	}
+
+	return d.unscaled.Int64(), nil
+}
@@ -0,0 +1,400 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coltypes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func Test_formatDecimal(t *testing.T) {
+	t.Parallel()
+
+	bigUnscaled, _ := new(big.Int).SetString("1234567890123456789012345678901", 10)
+
+	tests := []struct {
+		name   string
+		value  string
+		scale  int
+		format DecimalFormat
+		want   any
+	}{
+		{
+			name:   "string",
+			value:  "123.45",
+			scale:  2,
+			format: DecimalFormatString,
+			want:   "123.45",
+		},
+		{
+			name:   "string, pads scale",
+			value:  "-1.5",
+			scale:  3,
+			format: DecimalFormatString,
+			want:   "-1.500",
+		},
+		{
+			name:   "string, 31 digits",
+			value:  "12345678901234567890123456789.01",
+			scale:  2,
+			format: DecimalFormatString,
+			want:   "12345678901234567890123456789.01",
+		},
+		{
+			name:   "scaled",
+			value:  "123.45",
+			scale:  2,
+			format: DecimalFormatScaled,
+			want:   int64(12345),
+		},
+		{
+			name:   "scaled, exceeds int64",
+			value:  "12345678901234567890123456789.01",
+			scale:  2,
+			format: DecimalFormatScaled,
+			want:   bigUnscaled,
+		},
+		{
+			name:   "avro",
+			value:  "1.28",
+			scale:  2,
+			format: DecimalFormatAvro,
+			want:   []byte{0x00, 0x80},
+		},
+		{
+			name:   "avro, negative",
+			value:  "-1.29",
+			scale:  2,
+			format: DecimalFormatAvro,
+			want:   []byte{0xff, 0x7f},
+		},
+		{
+			name:   "avro, zero",
+			value:  "0",
+			scale:  0,
+			format: DecimalFormatAvro,
+			want:   []byte{0x00},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			got, err := formatDecimal([]byte(tt.value), tt.scale, tt.format)
+			is.NoErr(err)
+
+			if want, ok := tt.want.(*big.Int); ok {
+				is.Equal(got.(*big.Int).Cmp(want), 0)
+
+				return
+			}
+
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+func Test_avroBytesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"0", "1", "-1", "127", "-128", "128", "-129", "-32768", "98765432109876543210"} {
+		value := value
+		t.Run(value, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			d, err := parseDecimal(value)
+			is.NoErr(err)
+
+			is.Equal(decimalFromAvroBytes(d.avroBytes(), 0).String(), value)
+		})
+	}
+}
+
+func Test_convertDecimal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		value     any
+		precision int
+		scale     int
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "json.Number",
+			value:     json.Number("12345678901234567890.123456789"),
+			precision: 31,
+			scale:     9,
+			want:      "12345678901234567890.123456789",
+		},
+		{
+			name:      "json.Number with exponent",
+			value:     json.Number("1.5e2"),
+			precision: 5,
+			scale:     2,
+			want:      "150.00",
+		},
+		{
+			name:      "float64",
+			value:     float64(0.1),
+			precision: 5,
+			scale:     2,
+			want:      "0.10",
+		},
+		{
+			name:      "float32",
+			value:     float32(1.25),
+			precision: 5,
+			scale:     2,
+			want:      "1.25",
+		},
+		{
+			name:      "int64",
+			value:     int64(42),
+			precision: 5,
+			scale:     0,
+			want:      "42",
+		},
+		{
+			name:      "string",
+			value:     " -7.05 ",
+			precision: 5,
+			scale:     2,
+			want:      "-7.05",
+		},
+		{
+			name:      "trailing zeros",
+			value:     "1.2300",
+			precision: 5,
+			scale:     2,
+			want:      "1.23",
+		},
+		{
+			name:      "fractional digits lost",
+			value:     json.Number("1.235"),
+			precision: 5,
+			scale:     2,
+			wantErr:   ErrDecimalScale,
+		},
+		{
+			name:      "avro bytes",
+			value:     []byte{0xff, 0x80},
+			precision: 5,
+			scale:     2,
+			want:      "-1.28",
+		},
+		{
+			name:      "overflow",
+			value:     json.Number("1000"),
+			precision: 5,
+			scale:     2,
+			wantErr:   ErrDecimalOverflow,
+		},
+		{
+			name:      "invalid",
+			value:     "12a",
+			precision: 5,
+			scale:     2,
+			wantErr:   ErrInvalidDecimal,
+		},
+		{
+			name:      "exponent out of range",
+			value:     json.Number("1e999999999"),
+			precision: 5,
+			scale:     2,
+			wantErr:   ErrInvalidDecimal,
+		},
+		{
+			name:      "negative exponent out of range",
+			value:     json.Number("1e-999999999"),
+			precision: 5,
+			scale:     2,
+			wantErr:   ErrInvalidDecimal,
+		},
+		{
+			name:      "unsupported type",
+			value:     true,
+			precision: 5,
+			scale:     2,
+			wantErr:   ErrInvalidDecimal,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			got, err := convertDecimal(tt.value, tt.precision, tt.scale)
+			if tt.wantErr != nil {
+				is.True(errors.Is(err, tt.wantErr))
+
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+func Test_convertInteger(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   any
+		bitSize int
+		want    int64
+		wantErr error
+	}{
+		{
+			name:    "json.Number beyond float64 precision",
+			value:   json.Number("9007199254740993"),
+			bitSize: 64,
+			want:    9007199254740993,
+		},
+		{
+			name:    "max int64",
+			value:   json.Number("9223372036854775807"),
+			bitSize: 64,
+			want:    9223372036854775807,
+		},
+		{
+			name:    "integral float64",
+			value:   float64(42),
+			bitSize: 32,
+			want:    42,
+		},
+		{
+			name:    "int32",
+			value:   int32(-5),
+			bitSize: 16,
+			want:    -5,
+		},
+		{
+			name:    "string",
+			value:   "123",
+			bitSize: 64,
+			want:    123,
+		},
+		{
+			name:    "fraction",
+			value:   float64(1.5),
+			bitSize: 64,
+			wantErr: ErrNotAnInteger,
+		},
+		{
+			name:    "smallint overflow",
+			value:   json.Number("32768"),
+			bitSize: 16,
+			wantErr: ErrIntegerOverflow,
+		},
+		{
+			name:    "bigint overflow",
+			value:   json.Number("9223372036854775808"),
+			bitSize: 64,
+			wantErr: ErrIntegerOverflow,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			got, err := convertInteger(tt.value, tt.bitSize)
+			if tt.wantErr != nil {
+				is.True(errors.Is(err, tt.wantErr))
+
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+func Test_convertDecFloat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{name: "int64", value: int64(10), want: "10"},
+		{name: "int32", value: int32(-3), want: "-3"},
+		{name: "float64", value: float64(2.5), want: "2.5"},
+		{name: "json.Number", value: json.Number("1234567890.0123456789012345678901234"),
+			want: "1234567890.0123456789012345678901234"},
+		{name: "infinity", value: "-Infinity", want: "-Infinity"},
+		{name: "nan", value: "nan", want: "NaN"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			got, err := convertDecFloat(tt.value)
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+func TestConvertStructureData_numeric(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	tableInfo := TableInfo{
+		ColumnTypes: map[string]string{
+			"ID":     bigintType,
+			"AMOUNT": decimalType,
+			"RATE":   decimalFloatType,
+		},
+		ColumnLengths: map[string]int{"ID": 8, "AMOUNT": 10, "RATE": 16},
+		ColumnScales:  map[string]int{"AMOUNT": 2},
+	}
+
+	got, err := ConvertStructureData(context.Background(), tableInfo, opencdc.StructuredData{
+		"id":     json.Number("9007199254740993"),
+		"amount": json.Number("12345.6"),
+		"rate":   int64(3),
+	}, ConvertOptions{})
+	is.NoErr(err)
+
+	is.Equal(got, opencdc.StructuredData{
+		"ID":     int64(9007199254740993),
+		"AMOUNT": "12345.60",
+		"RATE":   "3",
+	})
+}
@@ -0,0 +1,135 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coltypes
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// queryDescribe describes the result columns of the select statement of its argument.
+	queryDescribe = `CALL SYSPROC.ADMIN_CMD(?)`
+
+	// Result columns of DESCRIBE OUTPUT.
+	describeColumnName   = "SQLNAME_DATA"
+	describeColumnType   = "SQLTYPE"
+	describeColumnLength = "SQLLENGTH"
+	describeColumnScale  = "SQLSCALE"
+)
+
+// describeTypes maps type names of DESCRIBE OUTPUT, which differ from the names in SYSCAT.COLUMNS.
+var describeTypes = map[string]string{
+	"CHAR":      charType,
+	"TIMESTMP":  timeStamp,
+	"DECFLOAT":  decimalFloatType,
+	"LONG VARG": longVarGraphicType,
+}
+
+// DescribeQuery returns information about the result columns of the select query,
+// which is described with the DESCRIBE OUTPUT command of ADMIN_CMD. Queries don't have primary keys,
+// and the names of the result columns must be unique.
+func DescribeQuery(ctx context.Context, querier Querier, query string) (TableInfo, error) {
+	rows, err := querier.QueryContext(ctx, queryDescribe, "DESCRIBE OUTPUT "+query)
+	if err != nil {
+		return TableInfo{}, fmt.Errorf("describe query: %w", err)
+	}
+
+	defer rows.Close()
+
+	names, err := rows.Columns()
+	if err != nil {
+		return TableInfo{}, fmt.Errorf("get describe columns: %w", err)
+	}
+
+	tableInfo := TableInfo{
+		ColumnTypes:   make(map[string]string),
+		ColumnLengths: make(map[string]int),
+		ColumnScales:  make(map[string]int),
+		ForBitData:    make(map[string]bool),
+		PrimaryKeys:   make([]string, 0),
+	}
+
+	for rows.Next() {
+		values := make([]any, len(names))
+		dest := make([]any, len(names))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+
+		if er := rows.Scan(dest...); er != nil {
+			return TableInfo{}, fmt.Errorf("scan rows: %w", er)
+		}
+
+		row := make(map[string]any, len(names))
+		for i, name := range names {
+			row[strings.ToUpper(name)] = values[i]
+		}
+
+		columnName := describeString(row[describeColumnName])
+		if _, ok := tableInfo.ColumnTypes[columnName]; ok {
+			return TableInfo{}, fmt.Errorf("%w: %q", ErrDuplicateColumn, columnName)
+		}
+
+		dataType := strings.ToUpper(strings.TrimSpace(describeString(row[describeColumnType])))
+		if name, ok := describeTypes[dataType]; ok {
+			dataType = name
+		}
+
+		tableInfo.ColumnTypes[columnName] = dataType
+		tableInfo.ColumnLengths[columnName] = describeInt(row[describeColumnLength])
+		tableInfo.ColumnScales[columnName] = describeInt(row[describeColumnScale])
+	}
+	if err := rows.Err(); err != nil {
+		return TableInfo{}, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return tableInfo, nil
+}
+
+// describeString returns a character value of the DESCRIBE output.
+func describeString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return ""
+	}
+}
+
+// describeInt returns an integer value of the DESCRIBE output, it is 0 if the value is missing.
+func describeInt(value any) int {
+	switch v := value.(type) {
+	case int64:
+		return int(v)
+	case int32:
+		return int(v)
+	case int16:
+		return int(v)
+	case int:
+		return v
+	default:
+		i, err := strconv.Atoi(strings.TrimSpace(describeString(value)))
+		if err != nil {
+			return 0
+		}
+
+		return i
+	}
+}
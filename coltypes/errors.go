@@ -24,6 +24,16 @@ var (
 	ErrValueIsNotAString         = errors.New("value is not a string")
 	ErrConvertDecFloat           = errors.New("cannot convert DECFLOAT")
 	ErrInvalidTimeLayout         = errors.New("invalid time layout")
+	ErrInvalidDecimal            = errors.New("invalid decimal value")
+	ErrDecimalOverflow           = errors.New("decimal value exceeds column precision")
+	ErrDecimalScale              = errors.New("decimal value has more fractional digits than column scale")
+	ErrNotAnInteger              = errors.New("value is not an integer")
+	ErrIntegerOverflow           = errors.New("integer value out of range")
+	ErrInvalidBoolean            = errors.New("invalid boolean value")
+	ErrInvalidTimestamp          = errors.New("invalid timestamp value")
+	ErrInvalidBinary             = errors.New("invalid binary value")
+	ErrLOBTooLarge               = errors.New("LOB value is too large")
+	ErrDuplicateColumn           = errors.New("duplicate column name")
 )
 
 // convertValueToBytesErr returns the formatted ErrCannotConvertValueToBytes error.
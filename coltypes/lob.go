@@ -0,0 +1,181 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coltypes
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/conduitio-labs/conduit-connector-db2/common"
+)
+
+// LOBPolicyType is a way of reading CLOB, BLOB and DBCLOB columns in the source.
+type LOBPolicyType string
+
+const (
+	// LOBPolicyInclude reads the whole LOB value.
+	LOBPolicyInclude LOBPolicyType = "include"
+	// LOBPolicyTruncate reads the first bytes of the LOB value.
+	LOBPolicyTruncate LOBPolicyType = "truncate"
+	// LOBPolicyHash replaces the LOB value with its hex-encoded SHA-256 hash, computed by the database.
+	LOBPolicyHash LOBPolicyType = "hash"
+	// LOBPolicyExclude doesn't read the LOB column.
+	LOBPolicyExclude LOBPolicyType = "exclude"
+)
+
+// hashAlgorithmSHA256 is an algorithm argument of the DB2 HASH function.
+const hashAlgorithmSHA256 = 2
+
+// LOBPolicy is a policy of reading a LOB column.
+type LOBPolicy struct {
+	// Type is a way of reading the column.
+	Type LOBPolicyType
+	// TruncateBytes is a number of bytes read by the LOBPolicyTruncate policy.
+	TruncateBytes int
+}
+
+// LOBPolicies holds policies of reading LOB columns.
+type LOBPolicies struct {
+	// Default is a policy of LOB columns without their own policy.
+	Default LOBPolicy
+	// Columns - column name with its policy.
+	Columns map[string]LOBPolicy
+}
+
+// policy returns the policy of the column, LOBPolicyInclude if no policy is set.
+func (p LOBPolicies) policy(column string) LOBPolicy {
+	policy, ok := p.Columns[column]
+	if !ok {
+		policy = p.Default
+	}
+
+	if policy.Type == "" {
+		policy.Type = LOBPolicyInclude
+	}
+
+	return policy
+}
+
+// BinaryEncoding is an encoding of string values written to binary columns by the destination.
+type BinaryEncoding string
+
+const (
+	// BinaryEncodingRaw writes bytes of the string as is.
+	BinaryEncodingRaw BinaryEncoding = "raw"
+	// BinaryEncodingBase64 decodes standard base64 strings, the way JSON encodes byte slices.
+	BinaryEncodingBase64 BinaryEncoding = "base64"
+	// BinaryEncodingHex decodes hex strings.
+	BinaryEncodingHex BinaryEncoding = "hex"
+)
+
+// isLOB returns true if the column type is CLOB, BLOB or DBCLOB.
+func isLOB(colType string) bool {
+	return colType == clobType || colType == blobType || colType == dbClobType
+}
+
+// selectLOB returns an expression for selecting the LOB column according to its policy,
+// and false if the column must not be selected.
+func selectLOB(column, colType string, policy LOBPolicy) (string, bool) {
+	column = common.QuoteIdentifier(column)
+
+	switch policy.Type {
+	case LOBPolicyExclude:
+		return "", false
+
+	case LOBPolicyHash:
+		return fmt.Sprintf("HASH(%s, %d) AS %s", column, hashAlgorithmSHA256, column), true
+
+	case LOBPolicyTruncate:
+		switch colType {
+		case clobType:
+			// OCTETS doesn't split multibyte characters, they are replaced with blanks.
+			return fmt.Sprintf("SUBSTRING(%s, 1, %d, OCTETS) AS %s", column, policy.TruncateBytes, column), true
+		case dbClobType:
+			// DBCLOB length is in double-byte characters.
+			return fmt.Sprintf("SUBSTR(%s, 1, %d) AS %s", column, max(policy.TruncateBytes/2, 1), column), true
+		default:
+			return fmt.Sprintf("SUBSTR(%s, 1, %d) AS %s", column, policy.TruncateBytes, column), true
+		}
+
+	default:
+		return column, true
+	}
+}
+
+// convertBinary converts a string or byte slice value to bytes, decoding strings with the encoding.
+// The size of the decoded value is checked against maxBytes before the value is allocated.
+func convertBinary(value any, encoding BinaryEncoding, maxBytes int) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		if err := checkLOBSize(len(v), maxBytes); err != nil {
+			return nil, err
+		}
+
+		return v, nil
+
+	case string:
+		switch encoding {
+		case BinaryEncodingBase64:
+			if err := checkLOBSize(base64DecodedLen(v), maxBytes); err != nil {
+				return nil, err
+			}
+
+			b, err := base64.StdEncoding.DecodeString(v)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidBinary, err)
+			}
+
+			return b, nil
+
+		case BinaryEncodingHex:
+			if err := checkLOBSize(hex.DecodedLen(len(v)), maxBytes); err != nil {
+				return nil, err
+			}
+
+			b, err := hex.DecodeString(v)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidBinary, err)
+			}
+
+			return b, nil
+
+		default:
+			if err := checkLOBSize(len(v), maxBytes); err != nil {
+				return nil, err
+			}
+
+			return []byte(v), nil
+		}
+
+	default:
+		return nil, ErrValueIsNotAString
+	}
+}
+
+// base64DecodedLen returns the length of the decoded padded base64 string.
+func base64DecodedLen(s string) int {
+	return base64.StdEncoding.DecodedLen(len(s)) - (len(s) - len(strings.TrimRight(s, "=")))
+}
+
+// checkLOBSize returns an error if the size exceeds maxBytes, zero maxBytes means no limit.
+func checkLOBSize(size, maxBytes int) error {
+	if maxBytes > 0 && size > maxBytes {
+		return fmt.Errorf("%d bytes exceeds the limit of %d bytes: %w", size, maxBytes, ErrLOBTooLarge)
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coltypes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestTableInfo_SelectColumns_lobPolicies(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	tableInfo := TableInfo{
+		ColumnTypes: map[string]string{
+			"ID": integerType, "DOC": clobType, "IMAGE": blobType, "NOTES": dbClobType, "RAW": blobType,
+		},
+	}
+
+	lobPolicies := LOBPolicies{
+		Default: LOBPolicy{Type: LOBPolicyTruncate, TruncateBytes: 100},
+		Columns: map[string]LOBPolicy{
+			"IMAGE": {Type: LOBPolicyHash},
+			"RAW":   {Type: LOBPolicyExclude},
+		},
+	}
+
+	is.Equal(tableInfo.SelectColumns(nil, lobPolicies), []string{
+		`SUBSTRING("DOC", 1, 100, OCTETS) AS "DOC"`,
+		`"ID"`,
+		`HASH("IMAGE", 2) AS "IMAGE"`,
+		`SUBSTR("NOTES", 1, 50) AS "NOTES"`,
+	})
+	is.Equal(tableInfo.SelectColumns([]string{"ID", "RAW", "DOC"}, LOBPolicies{}),
+		[]string{`"ID"`, `"RAW"`, `"DOC"`})
+}
+
+func TestTransformRow_lobHash(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	tableInfo := TableInfo{ColumnTypes: map[string]string{"DOC": clobType}}
+
+	got, err := TransformRow(context.Background(), map[string]any{"DOC": []byte{0xde, 0xad, 0xbe, 0xef}}, tableInfo,
+		TransformOptions{LOBPolicies: LOBPolicies{Default: LOBPolicy{Type: LOBPolicyHash}}})
+	is.NoErr(err)
+	is.Equal(got, map[string]any{"DOC": "deadbeef"})
+}
+
+func TestConvertStructureData_lob(t *testing.T) {
+	t.Parallel()
+
+	tableInfo := TableInfo{
+		ColumnTypes: map[string]string{"IMAGE": blobType, "DOC": clobType, "CODE": varbinaryType},
+	}
+
+	tests := []struct {
+		name     string
+		column   string
+		value    any
+		encoding BinaryEncoding
+		maxBytes int
+		want     any
+		wantErr  error
+	}{
+		{
+			name:     "raw",
+			column:   "image",
+			value:    "abc",
+			encoding: BinaryEncodingRaw,
+			want:     []byte("abc"),
+		},
+		{
+			name:     "base64",
+			column:   "image",
+			value:    "3q2+7w==",
+			encoding: BinaryEncodingBase64,
+			maxBytes: 4,
+			want:     []byte{0xde, 0xad, 0xbe, 0xef},
+		},
+		{
+			name:     "hex",
+			column:   "code",
+			value:    "deadbeef",
+			encoding: BinaryEncodingHex,
+			want:     []byte{0xde, 0xad, 0xbe, 0xef},
+		},
+		{
+			name:     "invalid base64",
+			column:   "image",
+			value:    "not base64!",
+			encoding: BinaryEncodingBase64,
+			wantErr:  ErrInvalidBinary,
+		},
+		{
+			name:     "base64 exceeds limit",
+			column:   "image",
+			value:    "3q2+7w==",
+			encoding: BinaryEncodingBase64,
+			maxBytes: 3,
+			wantErr:  ErrLOBTooLarge,
+		},
+		{
+			name:     "hex exceeds limit",
+			column:   "image",
+			value:    "deadbeef",
+			encoding: BinaryEncodingHex,
+			maxBytes: 3,
+			wantErr:  ErrLOBTooLarge,
+		},
+		{
+			name:     "clob exceeds limit",
+			column:   "doc",
+			value:    "abcd",
+			maxBytes: 3,
+			wantErr:  ErrLOBTooLarge,
+		},
+		{
+			name:     "limit doesn't apply to non-LOB columns",
+			column:   "code",
+			value:    "abcd",
+			maxBytes: 3,
+			want:     []byte("abcd"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			got, err := ConvertStructureData(context.Background(), tableInfo, opencdc.StructuredData{tt.column: tt.value},
+				ConvertOptions{BinaryEncoding: tt.encoding, LOBMaxBytes: tt.maxBytes})
+			if tt.wantErr != nil {
+				is.True(errors.Is(err, tt.wantErr))
+
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got[tableInfo.Column(tt.column)], tt.want)
+		})
+	}
+}
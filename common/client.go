@@ -0,0 +1,120 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// ConnectorName is the name of the connector, which identifies its sessions in DB2.
+const ConnectorName = "conduit-connector-db2"
+
+// maxClientInfoLength is the maximum length of the values of the client information registers in bytes.
+const maxClientInfoLength = 255
+
+// ConnectorVersion is the version of the connector, it is set by the connector package.
+var ConnectorVersion = "(devel)"
+
+// Role is the role of the connector in the pipeline.
+type Role string
+
+const (
+	// RoleSource is the role of the source connector.
+	RoleSource Role = "source"
+	// RoleDestination is the role of the destination connector.
+	RoleDestination Role = "destination"
+)
+
+// ClientInfo holds the values of the client information registers, which identify the sessions of the connector,
+// e.g. in MON_GET_CONNECTION.
+type ClientInfo struct {
+	// ApplName is the value of CLIENT_APPLNAME.
+	ApplName string
+	// UserID is the value of CLIENT_USERID.
+	UserID string
+	// WrkstnName is the value of CLIENT_WRKSTNNAME.
+	WrkstnName string
+	// Accounting is the value of CLIENT_ACCTNG.
+	Accounting string
+}
+
+// ClientInfo returns the client information of the connector role. Values which are not configured default to
+// the connector name and role, the pipeline, the connector ID and the connector version.
+func (c Configuration) ClientInfo(ctx context.Context, role Role) ClientInfo {
+	connectorID := sdk.ConnectorIDFromContext(ctx)
+	// connector IDs of Conduit are prefixed with the pipeline ID.
+	pipeline, _, _ := strings.Cut(connectorID, ":")
+
+	info := ClientInfo{
+		ApplName:   cmp.Or(c.ClientApplName, ConnectorName+"-"+string(role)),
+		UserID:     cmp.Or(c.ClientUserID, pipeline),
+		WrkstnName: cmp.Or(c.ClientWrkstnName, connectorID),
+		Accounting: cmp.Or(c.ClientAccounting, ConnectorName+" "+ConnectorVersion),
+	}
+
+	info.ApplName = truncate(info.ApplName, maxClientInfoLength)
+	info.UserID = truncate(info.UserID, maxClientInfoLength)
+	info.WrkstnName = truncate(info.WrkstnName, maxClientInfoLength)
+	info.Accounting = truncate(info.Accounting, maxClientInfoLength)
+
+	return info
+}
+
+// Keywords returns the CLI keywords which set the client information registers on every connection.
+func (i ClientInfo) Keywords() []Keyword {
+	return []Keyword{
+		{"ClientApplName", i.ApplName},
+		{"ClientUserID", i.UserID},
+		{"ClientWrkStnName", i.WrkstnName},
+		{"ClientAcctStr", i.Accounting},
+	}
+}
+
+// validateClientInfo validates the configured values of the client information registers.
+func (c Configuration) validateClientInfo() error {
+	for _, field := range [][2]string{
+		{ConfigurationClientApplName, c.ClientApplName},
+		{ConfigurationClientUserID, c.ClientUserID},
+		{ConfigurationClientWrkstnName, c.ClientWrkstnName},
+		{ConfigurationClientAccounting, c.ClientAccounting},
+	} {
+		name, value := field[0], field[1]
+		if len(value) > maxClientInfoLength || strings.ContainsFunc(value, unicode.IsControl) {
+			return fmt.Errorf("%q must be at most %d bytes without control characters", name, maxClientInfoLength)
+		}
+	}
+
+	return nil
+}
+
+// truncate returns the first bytes of the string, without splitting a multibyte character.
+func truncate(s string, length int) string {
+	if len(s) <= length {
+		return s
+	}
+
+	for length > 0 && !utf8.RuneStart(s[length]) {
+		length--
+	}
+
+	return s[:length]
+}
@@ -0,0 +1,62 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestConfiguration_ClientInfo(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	is.Equal(Configuration{}.ClientInfo(ctx, RoleDestination), ClientInfo{
+		ApplName:   "conduit-connector-db2-destination",
+		Accounting: "conduit-connector-db2 (devel)",
+	})
+
+	cfg := Configuration{
+		ClientApplName:   "orders-sync",
+		ClientUserID:     "etl",
+		ClientWrkstnName: "worker-1",
+		ClientAccounting: strings.Repeat("x", maxClientInfoLength),
+	}
+
+	is.Equal(cfg.ClientInfo(ctx, RoleSource), ClientInfo{
+		ApplName:   "orders-sync",
+		UserID:     "etl",
+		WrkstnName: "worker-1",
+		Accounting: strings.Repeat("x", maxClientInfoLength),
+	})
+	is.NoErr(cfg.validateClientInfo())
+
+	cfg.ClientUserID = "etl\n"
+	is.True(cfg.validateClientInfo() != nil)
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	is.Equal(truncate("pipeline", 4), "pipe")
+	is.Equal(truncate("pipeline", 10), "pipeline")
+	is.Equal(truncate("añb", 2), "a")
+}
@@ -17,7 +17,10 @@
 package common
 
 import (
-	"strings"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
 )
 
 const MaxConfigStringLength = 128
@@ -25,15 +28,78 @@ const MaxConfigStringLength = 128
 // Config contains configurable values
 // shared between source and destination DB2 connector.
 type Configuration struct {
-	// Connection string connection to DB2 database.
-	Connection string `json:"connection" validate:"required"`
+	// Connection string connection to DB2 database. The connection can be configured with the "host", "port",
+	// "database", "user" and password fields instead.
+	Connection string `json:"connection"`
+	// Host is a host name or an IP address of the DB2 server, it is used instead of the "connection" string.
+	Host string `json:"host"`
+	// Port is a port of the DB2 server.
+	Port int `json:"port" default:"50000" validate:"gt=0,lt=65536"`
+	// Database is a name of the database.
+	Database string `json:"database"`
+	// User is a name of the user the connector connects as.
+	User string `json:"user"`
+	// Password is a password of the user.
+	Password string `json:"password"`
+	// PasswordFile is a path of a file with the password of the user, a trailing line break is ignored.
+	PasswordFile string `json:"passwordFile"`
+	// PasswordEnv is a name of an environment variable with the password of the user.
+	PasswordEnv string `json:"passwordEnv"`
+	// SSL enables TLS connections to the server (SECURITY=SSL).
+	SSL bool `json:"ssl"`
+	// SSLServerCertificate is a path of the certificate of the server or of the CA that signed it.
+	// By default, the certificates of the client key database are trusted.
+	SSLServerCertificate string `json:"sslServerCertificate"`
+	// SSLClientKeystore is a path of the key database (.kdb) with the client certificate
+	// and trusted certificates.
+	SSLClientKeystore string `json:"sslClientKeystore"`
+	// SSLClientKeystash is a path of the stash file (.sth) with the password of the client key database.
+	SSLClientKeystash string `json:"sslClientKeystash"`
+	// CurrentSchema is the schema of unqualified table names, by default the schema of the user.
+	CurrentSchema string `json:"currentSchema"`
 	// Table is a name of the table that the connector should write to or read from.
 	Table string `json:"table" validate:"required"`
+	// Timezone is a time zone of the DB2 session, which is used to interpret DATE, TIME and TIMESTAMP values
+	// without time zone, e.g. "UTC" or "Europe/Berlin". By default, the local time zone of the connector is used.
+	Timezone string `json:"timezone"`
+	// Platform is the DB2 platform of the database, which selects the SQL dialect of the queries:
+	// "luw" (DB2 for Linux, UNIX and Windows), "zos" (DB2 for z/OS) or "ibmi" (DB2 for IBM i).
+	// Only "luw" is fully supported, options which rely on statements of other platforms are rejected.
+	Platform string `json:"platform" default:"luw" validate:"inclusion=luw|zos|ibmi"`
+	// MaxOpenConnections is a maximum number of open connections of the connector, 0 means no limit.
+	// The connector uses up to two connections at the same time, so the limit must be 0 or at least 2.
+	MaxOpenConnections int `json:"maxOpenConnections" default:"4" validate:"gt=-1"`
+	// MaxIdleConnections is a maximum number of idle connections kept open for reuse.
+	MaxIdleConnections int `json:"maxIdleConnections" default:"2" validate:"gt=-1"`
+	// ConnectionMaxLifetime is a maximum time a connection is reused, 0 means connections are reused forever.
+	ConnectionMaxLifetime time.Duration `json:"connectionMaxLifetime" default:"30m"`
+	// MaxRetries is a maximum number of retries of reads and writes failed with a transient error: a deadlock,
+	// a lock timeout or a communication failure, after which the connector reconnects. 0 disables retries.
+	MaxRetries int `json:"maxRetries" default:"3" validate:"gt=-1"`
+	// RetryBackoff is the time before the first retry, it is doubled for every next retry.
+	RetryBackoff time.Duration `json:"retryBackoff" default:"1s"`
+	// RetryMaxBackoff is the maximum time between retries.
+	RetryMaxBackoff time.Duration `json:"retryMaxBackoff" default:"30s"`
+	// ClientApplName is the CLIENT_APPLNAME of the sessions, by default "conduit-connector-db2-source"
+	// or "conduit-connector-db2-destination".
+	ClientApplName string `json:"clientApplName"`
+	// ClientUserID is the CLIENT_USERID of the sessions, by default the pipeline ID.
+	ClientUserID string `json:"clientUserID"`
+	// ClientWrkstnName is the CLIENT_WRKSTNNAME of the sessions, by default the connector ID.
+	ClientWrkstnName string `json:"clientWrkstnName"`
+	// ClientAccounting is the CLIENT_ACCTNG of the sessions, by default the connector name and version.
+	ClientAccounting string `json:"clientAccounting"`
 }
 
-// Init sets uppercase "table" name.
+// minOpenConnections is the number of connections the connector uses at the same time, e.g. the source reads
+// a batch while the tracking table is cleared.
+const minOpenConnections = 2
+
+// Init normalizes the "table" and "currentSchema" names, ordinary identifiers are folded to uppercase
+// and identifiers in double quotes keep their case.
 func (c Configuration) Init() Configuration {
-	c.Table = strings.ToUpper(c.Table)
+	c.Table = NormalizeIdentifier(c.Table)
+	c.CurrentSchema = NormalizeIdentifier(c.CurrentSchema)
 
 	return c
 }
@@ -44,5 +110,79 @@ func (c Configuration) Validate() error {
 		return NewLessThanError(ConfigurationTable, MaxConfigStringLength)
 	}
 
+	// the table is empty only if it is rendered from a template, which is validated by the destination.
+	if c.Table != "" {
+		if err := ValidateIdentifier(c.Table); err != nil {
+			return fmt.Errorf("%q: %w", ConfigurationTable, err)
+		}
+	}
+
+	if err := c.validateConnection(); err != nil {
+		return err
+	}
+
+	if err := c.validateClientInfo(); err != nil {
+		return err
+	}
+
+	if c.MaxOpenConnections > 0 && c.MaxOpenConnections < minOpenConnections {
+		return fmt.Errorf("%q must be 0 or at least %d", ConfigurationMaxOpenConnections, minOpenConnections)
+	}
+
+	if c.MaxOpenConnections < 0 || c.MaxIdleConnections < 0 || c.ConnectionMaxLifetime < 0 {
+		return fmt.Errorf("%q, %q and %q must not be negative", ConfigurationMaxOpenConnections,
+			ConfigurationMaxIdleConnections, ConfigurationConnectionMaxLifetime)
+	}
+
+	if c.MaxRetries < 0 || c.RetryBackoff < 0 || c.RetryMaxBackoff < c.RetryBackoff {
+		return fmt.Errorf("%q and %q must not be negative and %q must not be less than %q", ConfigurationMaxRetries,
+			ConfigurationRetryBackoff, ConfigurationRetryMaxBackoff, ConfigurationRetryBackoff)
+	}
+
+	if _, err := c.Location(); err != nil {
+		return err
+	}
+
 	return nil
 }
+
+// Pool returns the settings of the connection pool.
+func (c Configuration) Pool() Pool {
+	return Pool{
+		MaxOpen:     c.MaxOpenConnections,
+		MaxIdle:     c.MaxIdleConnections,
+		MaxLifetime: c.ConnectionMaxLifetime,
+	}
+}
+
+// Retry returns the settings of retrying operations failed with transient errors.
+// The connection pool is reconnected on communication failures.
+func (c Configuration) Retry(db *sql.DB) Retry {
+	return Retry{
+		MaxRetries: c.MaxRetries,
+		Backoff:    c.RetryBackoff,
+		MaxBackoff: c.RetryMaxBackoff,
+		Reconnect: func(ctx context.Context) error {
+			return c.Pool().Reconnect(ctx, db)
+		},
+	}
+}
+
+// Dialect returns the SQL dialect of the configured "platform".
+func (c Configuration) Dialect() Dialect {
+	return NewDialect(Platform(c.Platform))
+}
+
+// Location returns the location of the configured "timezone", or the local time zone if it is empty.
+func (c Configuration) Location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("load %q location: %w", c.Timezone, err)
+	}
+
+	return loc, nil
+}
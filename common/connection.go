@@ -0,0 +1,207 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrNoConnection occurs when neither the connection string nor the host is configured.
+var ErrNoConnection = errors.New(`either "connection" or "host" is required`)
+
+// Keyword is a keyword of the go_ibm_db connection string with its value.
+type Keyword struct {
+	Name  string
+	Value string
+}
+
+// ConnectionString returns the go_ibm_db connection string of the connector role: the "connection" string,
+// or the string built from the connection fields, with the password read from its file or environment variable,
+// followed by the client information keywords.
+func (c Configuration) ConnectionString(ctx context.Context, role Role) (string, error) {
+	connection := c.Connection
+
+	if connection == "" {
+		password, err := c.password()
+		if err != nil {
+			return "", err
+		}
+
+		keywords := []Keyword{
+			{"DATABASE", c.Database},
+			{"HOSTNAME", c.Host},
+			{"PORT", strconv.Itoa(c.Port)},
+			{"PROTOCOL", "TCPIP"},
+			{"UID", c.User},
+			{"PWD", password},
+		}
+
+		if c.SSL {
+			keywords = append(keywords,
+				Keyword{"SECURITY", "SSL"},
+				Keyword{"SSLServerCertificate", c.SSLServerCertificate},
+				Keyword{"SSLClientKeystoredb", c.SSLClientKeystore},
+				Keyword{"SSLClientKeystash", c.SSLClientKeystash},
+			)
+		}
+
+		connection = AppendKeywords("", append(keywords, Keyword{"CurrentSchema", c.CurrentSchema})...)
+	}
+
+	return AppendKeywords(connection, c.ClientInfo(ctx, role).Keywords()...), nil
+}
+
+// AppendKeywords appends the keywords with non-empty values to the connection string, escaping the values.
+func AppendKeywords(connection string, keywords ...Keyword) string {
+	var sb strings.Builder
+
+	sb.WriteString(connection)
+
+	if connection != "" && !strings.HasSuffix(connection, ";") {
+		sb.WriteString(";")
+	}
+
+	for _, keyword := range keywords {
+		if keyword.Value != "" {
+			sb.WriteString(keyword.Name + "=" + connectionValue(keyword.Value) + ";")
+		}
+	}
+
+	return sb.String()
+}
+
+// validateConnection validates that either the "connection" string or the connection fields are configured.
+func (c Configuration) validateConnection() error {
+	if c.Connection != "" {
+		fields := c.connectionFields()
+		for _, name := range slices.Sorted(maps.Keys(fields)) {
+			if fields[name] {
+				return fmt.Errorf("%q can't be combined with %q", name, ConfigurationConnection)
+			}
+		}
+
+		return nil
+	}
+
+	if c.Host == "" {
+		return ErrNoConnection
+	}
+
+	for _, field := range [][2]string{
+		{ConfigurationHost, c.Host},
+		{ConfigurationDatabase, c.Database},
+	} {
+		name, value := field[0], field[1]
+		if value == "" || strings.ContainsFunc(value, unicode.IsSpace) || strings.ContainsAny(value, ";{}=") {
+			return fmt.Errorf("%q must be a non-empty value without spaces and special characters", name)
+		}
+	}
+
+	if c.Port <= 0 || c.Port > 65535 {
+		return fmt.Errorf("%q must be between 1 and 65535", ConfigurationPort)
+	}
+
+	if strings.ContainsFunc(c.User, unicode.IsControl) {
+		return fmt.Errorf("%q must not contain control characters", ConfigurationUser)
+	}
+
+	passwords := 0
+	for _, value := range []string{c.Password, c.PasswordFile, c.PasswordEnv} {
+		if value != "" {
+			passwords++
+		}
+	}
+
+	if passwords > 1 {
+		return fmt.Errorf("only one of %q, %q and %q can be set",
+			ConfigurationPassword, ConfigurationPasswordFile, ConfigurationPasswordEnv)
+	}
+
+	if _, err := c.password(); err != nil {
+		return err
+	}
+
+	if !c.SSL && (c.SSLServerCertificate != "" || c.SSLClientKeystore != "" || c.SSLClientKeystash != "") {
+		return fmt.Errorf("%q, %q and %q require %q", ConfigurationSslServerCertificate,
+			ConfigurationSslClientKeystore, ConfigurationSslClientKeystash, ConfigurationSsl)
+	}
+
+	if c.CurrentSchema != "" {
+		if err := ValidateIdentifier(c.CurrentSchema); err != nil {
+			return fmt.Errorf("%q: %w", ConfigurationCurrentSchema, err)
+		}
+	}
+
+	return nil
+}
+
+// connectionFields returns whether the connection fields, except the "port" with its default, are set.
+func (c Configuration) connectionFields() map[string]bool {
+	return map[string]bool{
+		ConfigurationHost:                 c.Host != "",
+		ConfigurationDatabase:             c.Database != "",
+		ConfigurationUser:                 c.User != "",
+		ConfigurationPassword:             c.Password != "",
+		ConfigurationPasswordFile:         c.PasswordFile != "",
+		ConfigurationPasswordEnv:          c.PasswordEnv != "",
+		ConfigurationSsl:                  c.SSL,
+		ConfigurationSslServerCertificate: c.SSLServerCertificate != "",
+		ConfigurationSslClientKeystore:    c.SSLClientKeystore != "",
+		ConfigurationSslClientKeystash:    c.SSLClientKeystash != "",
+		ConfigurationCurrentSchema:        c.CurrentSchema != "",
+	}
+}
+
+// password returns the password of the user, read from the "passwordFile" or the "passwordEnv" if they are set.
+func (c Configuration) password() (string, error) {
+	switch {
+	case c.PasswordFile != "":
+		password, err := os.ReadFile(c.PasswordFile)
+		if err != nil {
+			return "", fmt.Errorf("read %q: %w", ConfigurationPasswordFile, err)
+		}
+
+		return strings.TrimRight(string(password), "\r\n"), nil
+
+	case c.PasswordEnv != "":
+		password, ok := os.LookupEnv(c.PasswordEnv)
+		if !ok {
+			return "", fmt.Errorf("%q: environment variable %q is not set", ConfigurationPasswordEnv, c.PasswordEnv)
+		}
+
+		return password, nil
+
+	default:
+		return c.Password, nil
+	}
+}
+
+// connectionValue returns the value of a connection string keyword. Values with special characters
+// or surrounding spaces are enclosed in braces, and the closing braces in them are doubled.
+func connectionValue(value string) string {
+	if !strings.ContainsAny(value, ";{}=") && strings.TrimSpace(value) == value {
+		return value
+	}
+
+	return "{" + strings.ReplaceAll(value, "}", "}}") + "}"
+}
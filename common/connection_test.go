@@ -0,0 +1,215 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// sourceClientKeywords are the default client information keywords of the source without a connector ID.
+const sourceClientKeywords = "ClientApplName=conduit-connector-db2-source;ClientAcctStr=conduit-connector-db2 (devel);"
+
+func TestConfiguration_ConnectionString(t *testing.T) {
+	t.Parallel()
+
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(passwordFile, []byte("from;file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		in   Configuration
+		want string
+	}{
+		{
+			name: "connection string",
+			in:   Configuration{Connection: "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd"},
+			want: "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd;",
+		},
+		{
+			name: "fields",
+			in: Configuration{
+				Host:     "db2.example.com",
+				Port:     50000,
+				Database: "testdb",
+				User:     "DB2INST1",
+				Password: "pwd",
+			},
+			want: "DATABASE=testdb;HOSTNAME=db2.example.com;PORT=50000;PROTOCOL=TCPIP;UID=DB2INST1;PWD=pwd;",
+		},
+		{
+			name: "escaped password",
+			in: Configuration{
+				Host:     "localhost",
+				Port:     50000,
+				Database: "testdb",
+				User:     "DB2INST1",
+				Password: "p;w}d=",
+			},
+			want: "DATABASE=testdb;HOSTNAME=localhost;PORT=50000;PROTOCOL=TCPIP;UID=DB2INST1;PWD={p;w}}d=};",
+		},
+		{
+			name: "password file",
+			in: Configuration{
+				Host:         "localhost",
+				Port:         50000,
+				Database:     "testdb",
+				User:         "DB2INST1",
+				PasswordFile: passwordFile,
+			},
+			want: "DATABASE=testdb;HOSTNAME=localhost;PORT=50000;PROTOCOL=TCPIP;UID=DB2INST1;PWD={from;file};",
+		},
+		{
+			name: "ssl and current schema",
+			in: Configuration{
+				Host:                 "localhost",
+				Port:                 50001,
+				Database:             "testdb",
+				User:                 "DB2INST1",
+				Password:             "pwd",
+				SSL:                  true,
+				SSLServerCertificate: "/certs/ca.arm",
+				CurrentSchema:        "APP",
+			},
+			want: "DATABASE=testdb;HOSTNAME=localhost;PORT=50001;PROTOCOL=TCPIP;UID=DB2INST1;PWD=pwd;" +
+				"SECURITY=SSL;SSLServerCertificate=/certs/ca.arm;CurrentSchema=APP;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			is.NoErr(tt.in.validateConnection())
+
+			got, err := tt.in.ConnectionString(context.Background(), RoleSource)
+			is.NoErr(err)
+			is.Equal(got, tt.want+sourceClientKeywords)
+		})
+	}
+}
+
+func TestConfiguration_ConnectionString_PasswordEnv(t *testing.T) {
+	is := is.New(t)
+
+	t.Setenv("DB2_TEST_PASSWORD", "from env")
+
+	cfg := Configuration{
+		Host:        "localhost",
+		Port:        50000,
+		Database:    "testdb",
+		User:        "DB2INST1",
+		PasswordEnv: "DB2_TEST_PASSWORD",
+	}
+
+	got, err := cfg.ConnectionString(context.Background(), RoleSource)
+	is.NoErr(err)
+	is.Equal(got, "DATABASE=testdb;HOSTNAME=localhost;PORT=50000;PROTOCOL=TCPIP;UID=DB2INST1;PWD=from env;"+
+		sourceClientKeywords)
+
+	cfg.PasswordEnv = "DB2_TEST_MISSING_PASSWORD"
+
+	_, err = cfg.ConnectionString(context.Background(), RoleSource)
+	is.True(err != nil)
+}
+
+func TestConfiguration_validateConnection(t *testing.T) {
+	t.Parallel()
+
+	valid := Configuration{Host: "localhost", Port: 50000, Database: "testdb", User: "DB2INST1", Password: "pwd"}
+
+	tests := []struct {
+		name    string
+		in      func(c Configuration) Configuration
+		wantErr string
+	}{
+		{
+			name: "no connection",
+			in: func(Configuration) Configuration {
+				return Configuration{}
+			},
+			wantErr: ErrNoConnection.Error(),
+		},
+		{
+			name: "connection string with fields",
+			in: func(c Configuration) Configuration {
+				c.Connection = "DATABASE=testdb"
+				return c
+			},
+			wantErr: `"database" can't be combined with "connection"`,
+		},
+		{
+			name: "host with special characters",
+			in: func(c Configuration) Configuration {
+				c.Host = "localhost;PWD=x"
+				return c
+			},
+			wantErr: `"host" must be a non-empty value without spaces and special characters`,
+		},
+		{
+			name: "missing database",
+			in: func(c Configuration) Configuration {
+				c.Database = ""
+				return c
+			},
+			wantErr: `"database" must be a non-empty value without spaces and special characters`,
+		},
+		{
+			name: "invalid port",
+			in: func(c Configuration) Configuration {
+				c.Port = 70000
+				return c
+			},
+			wantErr: `"port" must be between 1 and 65535`,
+		},
+		{
+			name: "several passwords",
+			in: func(c Configuration) Configuration {
+				c.PasswordEnv = "DB2_PASSWORD"
+				return c
+			},
+			wantErr: `only one of "password", "passwordFile" and "passwordEnv" can be set`,
+		},
+		{
+			name: "certificate without ssl",
+			in: func(c Configuration) Configuration {
+				c.SSLServerCertificate = "/certs/ca.arm"
+				return c
+			},
+			wantErr: `"sslServerCertificate", "sslClientKeystore" and "sslClientKeystash" require "ssl"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			err := tt.in(valid).validateConnection()
+			is.True(err != nil)
+			is.Equal(err.Error(), tt.wantErr)
+		})
+	}
+
+	is.New(t).True(errors.Is(Configuration{}.validateConnection(), ErrNoConnection))
+}
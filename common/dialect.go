@@ -0,0 +1,171 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// Platform is a DB2 platform the connector works with. The connector reads tables from the SYSCAT catalog views,
+// so only DB2 for LUW is fully supported, the other platforms select the dialect of statements and features.
+type Platform string
+
+const (
+	// PlatformLUW is DB2 for Linux, UNIX and Windows.
+	PlatformLUW Platform = "luw"
+	// PlatformZOS is DB2 for z/OS.
+	PlatformZOS Platform = "zos"
+	// PlatformIBMi is DB2 for IBM i.
+	PlatformIBMi Platform = "ibmi"
+)
+
+// Isolation is an isolation level of a single statement, which is set by the isolation clause.
+type Isolation string
+
+const (
+	// IsolationDefault keeps the isolation level of the connection.
+	IsolationDefault Isolation = ""
+	// IsolationNC is No Commit, which is only supported by DB2 for IBM i.
+	IsolationNC Isolation = "NC"
+	// IsolationUR is Uncommitted Read.
+	IsolationUR Isolation = "UR"
+	// IsolationCS is Cursor Stability.
+	IsolationCS Isolation = "CS"
+	// IsolationRS is Read Stability.
+	IsolationRS Isolation = "RS"
+	// IsolationRR is Repeatable Read.
+	IsolationRR Isolation = "RR"
+)
+
+// Feature is a feature of the connector which relies on statements that only some DB2 platforms support.
+type Feature string
+
+const (
+	// FeatureAdminCmd is a call of SYSPROC.ADMIN_CMD, which runs the LOAD utility and describes custom queries.
+	FeatureAdminCmd Feature = "SYSPROC.ADMIN_CMD"
+	// FeatureLockTimeout is the CURRENT LOCK TIMEOUT special register.
+	FeatureLockTimeout Feature = "CURRENT LOCK TIMEOUT"
+	// FeatureCurrentlyCommitted is the currently committed semantics of the cursor stability isolation.
+	FeatureCurrentlyCommitted Feature = "currently committed"
+)
+
+// featurePlatforms are the DB2 platforms which support the features.
+var featurePlatforms = map[Feature][]Platform{
+	FeatureAdminCmd:           {PlatformLUW},
+	FeatureLockTimeout:        {PlatformLUW},
+	FeatureCurrentlyCommitted: {PlatformLUW, PlatformZOS},
+}
+
+var (
+	// ErrUnsupportedIsolation occurs when the isolation level is not supported by the DB2 platform.
+	ErrUnsupportedIsolation = errors.New("isolation level is not supported by the platform")
+	// ErrUnsupportedFeature occurs when the feature is not supported by the DB2 platform.
+	ErrUnsupportedFeature = errors.New("feature is not supported by the platform")
+)
+
+// Dialect builds statements in the SQL dialect of a DB2 platform.
+// Queries are built without LIMIT, which DB2 for z/OS and DB2 for LUW without DB2_COMPATIBILITY_VECTOR reject,
+// the number of rows is limited with the FETCH FIRST clause instead.
+type Dialect struct {
+	platform Platform
+}
+
+// NewDialect creates a dialect of the DB2 platform, an empty platform is DB2 for LUW.
+func NewDialect(platform Platform) Dialect {
+	if platform == "" {
+		platform = PlatformLUW
+	}
+
+	return Dialect{platform: platform}
+}
+
+// Platform returns the DB2 platform of the dialect.
+func (d Dialect) Platform() Platform {
+	return d.platform
+}
+
+// ValidateIsolation returns an error if the platform doesn't support the isolation level.
+func (d Dialect) ValidateIsolation(isolation Isolation) error {
+	switch isolation {
+	case IsolationDefault, IsolationUR, IsolationCS, IsolationRS, IsolationRR:
+		return nil
+	case IsolationNC:
+		if d.platform == PlatformIBMi {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%q on %q: %w", isolation, d.platform, ErrUnsupportedIsolation)
+}
+
+// ValidateFeature returns an error if the platform doesn't support the feature.
+func (d Dialect) ValidateFeature(feature Feature) error {
+	if slices.Contains(featurePlatforms[feature], d.platform) {
+		return nil
+	}
+
+	return fmt.Errorf("%q on %q: %w", feature, d.platform, ErrUnsupportedFeature)
+}
+
+// SelectOptions are the clauses which follow the fullselect of a select statement.
+type SelectOptions struct {
+	// Offset is a number of rows skipped by the OFFSET clause, the clause is omitted if it is zero.
+	Offset int
+	// Limit is a number of rows fetched by the FETCH FIRST clause, the clause is omitted if it is zero.
+	Limit int
+	// ReadOnly adds the FOR READ ONLY clause, which lets DB2 use blocking and avoid update locks.
+	ReadOnly bool
+	// Isolation adds the isolation clause, the isolation level of the connection is used if it is empty.
+	Isolation Isolation
+}
+
+// BuildSelect returns the query and the arguments of the select builder followed by the OFFSET, FETCH FIRST,
+// FOR READ ONLY and isolation clauses, in the order of the DB2 select-statement.
+// The builder must not set a limit or an offset itself.
+func (d Dialect) BuildSelect(builder *sqlbuilder.SelectBuilder, opts SelectOptions) (string, []any) {
+	query, args := builder.Build()
+
+	return d.SelectClauses(query, opts), args
+}
+
+// SelectClauses appends the OFFSET, FETCH FIRST, FOR READ ONLY and isolation clauses to the select query.
+func (d Dialect) SelectClauses(query string, opts SelectOptions) string {
+	var sb strings.Builder
+
+	sb.WriteString(query)
+
+	if opts.Offset > 0 {
+		fmt.Fprintf(&sb, " OFFSET %d ROWS", opts.Offset)
+	}
+
+	if opts.Limit > 0 {
+		fmt.Fprintf(&sb, " FETCH FIRST %d ROWS ONLY", opts.Limit)
+	}
+
+	if opts.ReadOnly {
+		sb.WriteString(" FOR READ ONLY")
+	}
+
+	if opts.Isolation != IsolationDefault {
+		sb.WriteString(" WITH " + string(opts.Isolation))
+	}
+
+	return sb.String()
+}
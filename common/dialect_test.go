@@ -0,0 +1,120 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/matryer/is"
+)
+
+func TestDialect_BuildSelect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		platform Platform
+		opts     SelectOptions
+		want     string
+	}{
+		{
+			name:     "no clauses",
+			platform: PlatformLUW,
+			want:     `SELECT "ID", "NAME" FROM "USERS" WHERE "ID" > ? ORDER BY "ID"`,
+		},
+		{
+			name:     "fetch first",
+			platform: PlatformLUW,
+			opts:     SelectOptions{Limit: 1000},
+			want:     `SELECT "ID", "NAME" FROM "USERS" WHERE "ID" > ? ORDER BY "ID" FETCH FIRST 1000 ROWS ONLY`,
+		},
+		{
+			name:     "read only batch on z/OS",
+			platform: PlatformZOS,
+			opts:     SelectOptions{Limit: 1000, ReadOnly: true},
+			want: `SELECT "ID", "NAME" FROM "USERS" WHERE "ID" > ? ORDER BY "ID" ` +
+				`FETCH FIRST 1000 ROWS ONLY FOR READ ONLY`,
+		},
+		{
+			name:     "offset and uncommitted read",
+			platform: PlatformLUW,
+			opts:     SelectOptions{Offset: 200, Limit: 100, ReadOnly: true, Isolation: IsolationUR},
+			want: `SELECT "ID", "NAME" FROM "USERS" WHERE "ID" > ? ORDER BY "ID" ` +
+				`OFFSET 200 ROWS FETCH FIRST 100 ROWS ONLY FOR READ ONLY WITH UR`,
+		},
+		{
+			name:     "cursor stability on IBM i",
+			platform: PlatformIBMi,
+			opts:     SelectOptions{Limit: 10, Isolation: IsolationCS},
+			want:     `SELECT "ID", "NAME" FROM "USERS" WHERE "ID" > ? ORDER BY "ID" FETCH FIRST 10 ROWS ONLY WITH CS`,
+		},
+		{
+			name:     "read stability",
+			platform: PlatformZOS,
+			opts:     SelectOptions{Isolation: IsolationRS},
+			want:     `SELECT "ID", "NAME" FROM "USERS" WHERE "ID" > ? ORDER BY "ID" WITH RS`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			builder := sqlbuilder.NewSelectBuilder()
+			builder.Select(`"ID"`, `"NAME"`).
+				From(`"USERS"`).
+				Where(builder.GreaterThan(`"ID"`, 10)).
+				OrderBy(`"ID"`)
+
+			query, args := NewDialect(tt.platform).BuildSelect(builder, tt.opts)
+			is.Equal(query, tt.want)
+			is.Equal(args, []any{10})
+		})
+	}
+}
+
+func TestDialect_ValidateIsolation(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	is.Equal(NewDialect("").Platform(), PlatformLUW)
+
+	for _, platform := range []Platform{PlatformLUW, PlatformZOS, PlatformIBMi} {
+		for _, isolation := range []Isolation{IsolationDefault, IsolationUR, IsolationCS, IsolationRS, IsolationRR} {
+			is.NoErr(NewDialect(platform).ValidateIsolation(isolation))
+		}
+	}
+
+	is.NoErr(NewDialect(PlatformIBMi).ValidateIsolation(IsolationNC))
+	is.True(errors.Is(NewDialect(PlatformLUW).ValidateIsolation(IsolationNC), ErrUnsupportedIsolation))
+	is.True(errors.Is(NewDialect(PlatformZOS).ValidateIsolation("XX"), ErrUnsupportedIsolation))
+}
+
+func TestDialect_ValidateFeature(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	for _, feature := range []Feature{FeatureAdminCmd, FeatureLockTimeout, FeatureCurrentlyCommitted} {
+		is.NoErr(NewDialect(PlatformLUW).ValidateFeature(feature))
+		is.True(errors.Is(NewDialect(PlatformIBMi).ValidateFeature(feature), ErrUnsupportedFeature))
+	}
+
+	is.NoErr(NewDialect(PlatformZOS).ValidateFeature(FeatureCurrentlyCommitted))
+	is.True(errors.Is(NewDialect(PlatformZOS).ValidateFeature(FeatureAdminCmd), ErrUnsupportedFeature))
+	is.True(errors.Is(NewDialect(PlatformZOS).ValidateFeature(FeatureLockTimeout), ErrUnsupportedFeature))
+}
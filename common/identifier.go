@@ -0,0 +1,75 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// MaxIdentifierLength is the maximum length of DB2 table and column names in bytes.
+const MaxIdentifierLength = 128
+
+// ErrInvalidIdentifier occurs when a table or column name is not a valid DB2 identifier.
+var ErrInvalidIdentifier = errors.New("invalid identifier")
+
+// ordinaryIdentifierRegexp matches ordinary DB2 identifiers, which DB2 folds to uppercase.
+var ordinaryIdentifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// NormalizeIdentifier returns the name of the object the configured identifier refers to, the way DB2 resolves it:
+// ordinary identifiers are folded to uppercase, e.g. "users" becomes "USERS", identifiers in double quotes
+// keep their case, e.g. `"Users"` becomes "Users", and other names are taken literally.
+func NormalizeIdentifier(identifier string) string {
+	identifier = strings.TrimSpace(identifier)
+
+	if len(identifier) >= 2 && strings.HasPrefix(identifier, `"`) && strings.HasSuffix(identifier, `"`) {
+		return strings.ReplaceAll(identifier[1:len(identifier)-1], `""`, `"`)
+	}
+
+	if ordinaryIdentifierRegexp.MatchString(identifier) {
+		return strings.ToUpper(identifier)
+	}
+
+	return identifier
+}
+
+// ValidateIdentifier returns an error if the name can't be a DB2 table or column name.
+func ValidateIdentifier(name string) error {
+	if strings.TrimSpace(name) == "" || len(name) > MaxIdentifierLength ||
+		strings.ContainsFunc(name, unicode.IsControl) {
+		return fmt.Errorf("%q: %w", name, ErrInvalidIdentifier)
+	}
+
+	return nil
+}
+
+// QuoteIdentifier returns the name as a delimited identifier, which refers to the object with exactly this name.
+// Double quotes in the name are escaped, so the name can't change the meaning of the statement.
+func QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// QuoteIdentifiers returns the names as delimited identifiers.
+func QuoteIdentifiers(names []string) []string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = QuoteIdentifier(name)
+	}
+
+	return quoted
+}
@@ -0,0 +1,71 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestNormalizeIdentifier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		identifier string
+		want       string
+	}{
+		{identifier: "users", want: "USERS"},
+		{identifier: " Order_Items ", want: "ORDER_ITEMS"},
+		{identifier: `"Users"`, want: "Users"},
+		{identifier: `"say ""hi"""`, want: `say "hi"`},
+		{identifier: "order-items", want: "order-items"},
+		{identifier: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.identifier, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			is.Equal(NormalizeIdentifier(tt.identifier), tt.want)
+		})
+	}
+}
+
+func TestValidateIdentifier(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	is.NoErr(ValidateIdentifier("USERS"))
+	is.NoErr(ValidateIdentifier(`Users"; DROP TABLE USERS; --`))
+	is.NoErr(ValidateIdentifier(strings.Repeat("A", MaxIdentifierLength)))
+
+	is.True(errors.Is(ValidateIdentifier(""), ErrInvalidIdentifier))
+	is.True(errors.Is(ValidateIdentifier("   "), ErrInvalidIdentifier))
+	is.True(errors.Is(ValidateIdentifier("USERS\x00"), ErrInvalidIdentifier))
+	is.True(errors.Is(ValidateIdentifier(strings.Repeat("A", MaxIdentifierLength+1)), ErrInvalidIdentifier))
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	is.Equal(QuoteIdentifier("USERS"), `"USERS"`)
+	is.Equal(QuoteIdentifier(`Users"; DROP TABLE USERS; --`), `"Users""; DROP TABLE USERS; --"`)
+	is.Equal(QuoteIdentifiers([]string{"ID", "Name"}), []string{`"ID"`, `"Name"`})
+}
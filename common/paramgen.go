@@ -8,20 +8,185 @@ import (
 )
 
 const (
-	ConfigurationConnection = "connection"
-	ConfigurationTable      = "table"
+	ConfigurationClientAccounting      = "clientAccounting"
+	ConfigurationClientApplName        = "clientApplName"
+	ConfigurationClientUserID          = "clientUserID"
+	ConfigurationClientWrkstnName      = "clientWrkstnName"
+	ConfigurationConnection            = "connection"
+	ConfigurationConnectionMaxLifetime = "connectionMaxLifetime"
+	ConfigurationCurrentSchema         = "currentSchema"
+	ConfigurationDatabase              = "database"
+	ConfigurationHost                  = "host"
+	ConfigurationMaxIdleConnections    = "maxIdleConnections"
+	ConfigurationMaxOpenConnections    = "maxOpenConnections"
+	ConfigurationMaxRetries            = "maxRetries"
+	ConfigurationPassword              = "password"
+	ConfigurationPasswordEnv           = "passwordEnv"
+	ConfigurationPasswordFile          = "passwordFile"
+	ConfigurationPlatform              = "platform"
+	ConfigurationPort                  = "port"
+	ConfigurationRetryBackoff          = "retryBackoff"
+	ConfigurationRetryMaxBackoff       = "retryMaxBackoff"
+	ConfigurationSsl                   = "ssl"
+	ConfigurationSslClientKeystash     = "sslClientKeystash"
+	ConfigurationSslClientKeystore     = "sslClientKeystore"
+	ConfigurationSslServerCertificate  = "sslServerCertificate"
+	ConfigurationTable                 = "table"
+	ConfigurationTimezone              = "timezone"
+	ConfigurationUser                  = "user"
 )
 
 func (Configuration) Parameters() map[string]config.Parameter {
 	return map[string]config.Parameter{
+		ConfigurationClientAccounting: {
+			Default:     "",
+			Description: "ClientAccounting is the CLIENT_ACCTNG of the sessions, by default the connector name and version.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigurationClientApplName: {
+			Default:     "",
+			Description: "ClientApplName is the CLIENT_APPLNAME of the sessions, by default \"conduit-connector-db2-source\"\nor \"conduit-connector-db2-destination\".",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigurationClientUserID: {
+			Default:     "",
+			Description: "ClientUserID is the CLIENT_USERID of the sessions, by default the pipeline ID.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigurationClientWrkstnName: {
+			Default:     "",
+			Description: "ClientWrkstnName is the CLIENT_WRKSTNNAME of the sessions, by default the connector ID.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
 		ConfigurationConnection: {
 			Default:     "",
-			Description: "Connection string connection to DB2 database.",
+			Description: "Connection string connection to DB2 database. The connection can be configured with the \"host\", \"port\",\n\"database\", \"user\" and password fields instead.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigurationConnectionMaxLifetime: {
+			Default:     "30m",
+			Description: "ConnectionMaxLifetime is a maximum time a connection is reused, 0 means connections are reused forever.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigurationCurrentSchema: {
+			Default:     "",
+			Description: "CurrentSchema is the schema of unqualified table names, by default the schema of the user.",
 			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigurationDatabase: {
+			Default:     "",
+			Description: "Database is a name of the database.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigurationHost: {
+			Default:     "",
+			Description: "Host is a host name or an IP address of the DB2 server, it is used instead of the \"connection\" string.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigurationMaxIdleConnections: {
+			Default:     "2",
+			Description: "MaxIdleConnections is a maximum number of idle connections kept open for reuse.",
+			Type:        config.ParameterTypeInt,
 			Validations: []config.Validation{
-				config.ValidationRequired{},
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigurationMaxOpenConnections: {
+			Default:     "4",
+			Description: "MaxOpenConnections is a maximum number of open connections of the connector, 0 means no limit.\nThe connector uses up to two connections at the same time, so the limit must be 0 or at least 2.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigurationMaxRetries: {
+			Default:     "3",
+			Description: "MaxRetries is a maximum number of retries of reads and writes failed with a transient error: a deadlock,\na lock timeout or a communication failure, after which the connector reconnects. 0 disables retries.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
 			},
 		},
+		ConfigurationPassword: {
+			Default:     "",
+			Description: "Password is a password of the user.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigurationPasswordEnv: {
+			Default:     "",
+			Description: "PasswordEnv is a name of an environment variable with the password of the user.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigurationPasswordFile: {
+			Default:     "",
+			Description: "PasswordFile is a path of a file with the password of the user, a trailing line break is ignored.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigurationPlatform: {
+			Default:     "luw",
+			Description: "Platform is the DB2 platform of the database, which selects the SQL dialect of the queries:\n\"luw\" (DB2 for Linux, UNIX and Windows), \"zos\" (DB2 for z/OS) or \"ibmi\" (DB2 for IBM i).\nOnly \"luw\" is fully supported, options which rely on statements of other platforms are rejected.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"luw", "zos", "ibmi"}},
+			},
+		},
+		ConfigurationPort: {
+			Default:     "50000",
+			Description: "Port is a port of the DB2 server.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
+				config.ValidationLessThan{V: 65536},
+			},
+		},
+		ConfigurationRetryBackoff: {
+			Default:     "1s",
+			Description: "RetryBackoff is the time before the first retry, it is doubled for every next retry.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigurationRetryMaxBackoff: {
+			Default:     "30s",
+			Description: "RetryMaxBackoff is the maximum time between retries.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigurationSsl: {
+			Default:     "",
+			Description: "SSL enables TLS connections to the server (SECURITY=SSL).",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigurationSslClientKeystash: {
+			Default:     "",
+			Description: "SSLClientKeystash is a path of the stash file (.sth) with the password of the client key database.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigurationSslClientKeystore: {
+			Default:     "",
+			Description: "SSLClientKeystore is a path of the key database (.kdb) with the client certificate\nand trusted certificates.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigurationSslServerCertificate: {
+			Default:     "",
+			Description: "SSLServerCertificate is a path of the certificate of the server or of the CA that signed it.\nBy default, the certificates of the client key database are trusted.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
 		ConfigurationTable: {
 			Default:     "",
 			Description: "Table is a name of the table that the connector should write to or read from.",
@@ -30,5 +195,17 @@ func (Configuration) Parameters() map[string]config.Parameter {
 				config.ValidationRequired{},
 			},
 		},
+		ConfigurationTimezone: {
+			Default:     "",
+			Description: "Timezone is a time zone of the DB2 session, which is used to interpret DATE, TIME and TIMESTAMP values\nwithout time zone, e.g. \"UTC\" or \"Europe/Berlin\". By default, the local time zone of the connector is used.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigurationUser: {
+			Default:     "",
+			Description: "User is a name of the user the connector connects as.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
 	}
 }
@@ -0,0 +1,71 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Pool holds the settings of the connection pool of a connector.
+type Pool struct {
+	// MaxOpen is a maximum number of open connections, 0 means no limit.
+	MaxOpen int
+	// MaxIdle is a maximum number of idle connections.
+	MaxIdle int
+	// MaxLifetime is a maximum time a connection is reused, 0 means connections are reused forever.
+	MaxLifetime time.Duration
+}
+
+// Connect opens the connection pool of a connector and checks that the database is reachable.
+// The session statements are executed on every new connection, see [Open].
+func Connect(ctx context.Context, connection string, pool Pool, statements ...string) (*sql.DB, error) {
+	db, err := Open(connection, statements...)
+	if err != nil {
+		return nil, fmt.Errorf("open db: %w", err)
+	}
+
+	pool.apply(db)
+
+	if err = db.PingContext(ctx); err != nil {
+		db.Close() //nolint:errcheck // the error of the ping is returned
+
+		return nil, fmt.Errorf("ping db: %w", err)
+	}
+
+	return db, nil
+}
+
+// Reconnect closes the idle connections of the pool, which can be broken after a communication failure,
+// and checks that a new connection can be opened.
+func (p Pool) Reconnect(ctx context.Context, db *sql.DB) error {
+	db.SetMaxIdleConns(0)
+	db.SetMaxIdleConns(p.MaxIdle)
+
+	if err := db.PingContext(ctx); err != nil {
+		return fmt.Errorf("ping db: %w", err)
+	}
+
+	return nil
+}
+
+// apply sets the settings on the pool of the database.
+func (p Pool) apply(db *sql.DB) {
+	db.SetMaxOpenConns(p.MaxOpen)
+	db.SetMaxIdleConns(p.MaxIdle)
+	db.SetConnMaxLifetime(p.MaxLifetime)
+}
@@ -0,0 +1,39 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestPool_apply(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	db := sql.OpenDB(newSessionConnector(&fakeDriver{}, "DATABASE=testdb", nil))
+	defer db.Close()
+
+	Configuration{
+		MaxOpenConnections:    4,
+		MaxIdleConnections:    2,
+		ConnectionMaxLifetime: time.Minute,
+	}.Pool().apply(db)
+
+	is.Equal(db.Stats().MaxOpenConnections, 4)
+}
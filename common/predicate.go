@@ -0,0 +1,228 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidPredicate occurs when a filter is not a single SQL predicate, or a query is not a single subselect.
+var ErrInvalidPredicate = errors.New("invalid predicate")
+
+// predicateTokenKind is a kind of a token of a predicate.
+type predicateTokenKind int
+
+const (
+	// tokenOther is an operator, a number, a space or any other character.
+	tokenOther predicateTokenKind = iota
+	// tokenWord is an ordinary identifier or a keyword.
+	tokenWord
+	// tokenDelimited is a delimited identifier in double quotes.
+	tokenDelimited
+	// tokenString is a string literal in single quotes.
+	tokenString
+)
+
+// predicateToken is a token of a predicate, text is the token as it is written in the predicate.
+type predicateToken struct {
+	kind predicateTokenKind
+	text string
+}
+
+// ValidatePredicate returns an error if the predicate can't be used as a search condition of a WHERE
+// or WHEN clause on its own: it must not be empty, must not contain statement terminators, comments
+// or parameter markers, and its quotes and parentheses must be balanced.
+func ValidatePredicate(predicate string) error {
+	_, err := tokenizePredicate(predicate)
+
+	return err
+}
+
+// ValidateSelect returns an error if the query can't be used as a subselect on its own: it must start with SELECT
+// and follow the rules of [ValidatePredicate].
+func ValidateSelect(query string) error {
+	tokens, err := tokenizePredicate(query)
+	if err != nil {
+		return err
+	}
+
+	if first := significantToken(tokens, -1, 1); first.kind != tokenWord || !strings.EqualFold(first.text, "SELECT") {
+		return fmt.Errorf("query must start with SELECT: %w", ErrInvalidPredicate)
+	}
+
+	return nil
+}
+
+// QualifyColumns returns the predicate with the references of the columns qualified with the correlation name,
+// e.g. REGION = 'EU' becomes rw."REGION" = 'EU' for the column REGION. Names followed by a dot or a parenthesis,
+// or preceded by a dot or CURRENT, e.g. schema names, functions and special registers, are not qualified.
+func QualifyColumns(predicate, correlation string, columns []string) (string, error) {
+	tokens, err := tokenizePredicate(predicate)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+
+	for i, token := range tokens {
+		column, ok := token.column()
+		if ok && slices.Contains(columns, column) && !qualified(tokens, i) {
+			sb.WriteString(correlation + "." + QuoteIdentifier(column))
+
+			continue
+		}
+
+		sb.WriteString(token.text)
+	}
+
+	return sb.String(), nil
+}
+
+// column returns the name of the column an identifier token refers to.
+func (t predicateToken) column() (string, bool) {
+	switch t.kind {
+	case tokenWord:
+		return strings.ToUpper(t.text), true
+	case tokenDelimited:
+		return strings.ReplaceAll(t.text[1:len(t.text)-1], `""`, `"`), true
+	default:
+		return "", false
+	}
+}
+
+// qualified returns true if the identifier token is a part of a qualified name, a function name
+// or a special register, which are kept as they are.
+func qualified(tokens []predicateToken, i int) bool {
+	prev := significantToken(tokens, i, -1)
+	next := significantToken(tokens, i, 1)
+
+	return prev.text == "." || next.text == "." || next.text == "(" ||
+		(prev.kind == tokenWord && strings.EqualFold(prev.text, "CURRENT"))
+}
+
+// significantToken returns the nearest token in the direction, skipping spaces.
+func significantToken(tokens []predicateToken, i, direction int) predicateToken {
+	for j := i + direction; j >= 0 && j < len(tokens); j += direction {
+		if strings.TrimSpace(tokens[j].text) != "" {
+			return tokens[j]
+		}
+	}
+
+	return predicateToken{}
+}
+
+// tokenizePredicate splits the predicate into tokens and validates it, see [ValidatePredicate].
+func tokenizePredicate(predicate string) ([]predicateToken, error) {
+	if strings.TrimSpace(predicate) == "" {
+		return nil, fmt.Errorf("empty statement: %w", ErrInvalidPredicate)
+	}
+
+	var (
+		tokens []predicateToken
+		depth  int
+	)
+
+	for i := 0; i < len(predicate); {
+		c := predicate[i]
+
+		switch {
+		case c == '\'' || c == '"':
+			end := closingQuote(predicate, i)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated %c quote at %d: %w", c, i, ErrInvalidPredicate)
+			}
+
+			kind := tokenString
+			if c == '"' {
+				kind = tokenDelimited
+			}
+
+			tokens = append(tokens, predicateToken{kind: kind, text: predicate[i : end+1]})
+			i = end + 1
+
+			continue
+
+		case isWordStart(c):
+			end := i + 1
+			for end < len(predicate) && isWordPart(predicate[end]) {
+				end++
+			}
+
+			tokens = append(tokens, predicateToken{kind: tokenWord, text: predicate[i:end]})
+			i = end
+
+			continue
+
+		case c == ';', c == '?':
+			return nil, fmt.Errorf("%q at %d is not allowed: %w", string(c), i, ErrInvalidPredicate)
+
+		case strings.HasPrefix(predicate[i:], "--"), strings.HasPrefix(predicate[i:], "/*"):
+			return nil, fmt.Errorf("comment at %d is not allowed: %w", i, ErrInvalidPredicate)
+
+		case c == '(':
+			depth++
+
+		case c == ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parenthesis at %d: %w", i, ErrInvalidPredicate)
+			}
+		}
+
+		tokens = append(tokens, predicateToken{kind: tokenOther, text: predicate[i : i+1]})
+		i++
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses: %w", ErrInvalidPredicate)
+	}
+
+	return tokens, nil
+}
+
+// closingQuote returns the index of the quote which closes the quote at the start index, doubled quotes
+// are escaped quotes. It returns -1 if the quote is not closed.
+func closingQuote(s string, start int) int {
+	quote := s[start]
+
+	for i := start + 1; i < len(s); i++ {
+		if s[i] != quote {
+			continue
+		}
+
+		if i+1 < len(s) && s[i+1] == quote {
+			i++
+
+			continue
+		}
+
+		return i
+	}
+
+	return -1
+}
+
+// isWordStart returns true if the byte starts an ordinary identifier or a keyword.
+func isWordStart(c byte) bool {
+	return c == '_' || ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z')
+}
+
+// isWordPart returns true if the byte can be a part of an ordinary identifier or a keyword.
+func isWordPart(c byte) bool {
+	return isWordStart(c) || ('0' <= c && c <= '9')
+}
@@ -0,0 +1,80 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestValidatePredicate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		predicate string
+		valid     bool
+	}{
+		{predicate: "ID > 10", valid: true},
+		{predicate: `NAME = 'it''s; -- not a comment' AND "a""b" IS NULL`, valid: true},
+		{predicate: "(A = 1 OR B = 2) AND C - 1 > 0", valid: true},
+		{predicate: "   ", valid: false},
+		{predicate: "ID > 10; DELETE FROM T", valid: false},
+		{predicate: "ID > 10 -- comment", valid: false},
+		{predicate: "ID > 10 /* comment */", valid: false},
+		{predicate: "ID > ?", valid: false},
+		{predicate: "NAME = 'open", valid: false},
+		{predicate: `"open = 1`, valid: false},
+		{predicate: "ID > 1)", valid: false},
+		{predicate: "(ID > 1", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.predicate, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			err := ValidatePredicate(tt.predicate)
+			is.Equal(err == nil, tt.valid)
+			if err != nil {
+				is.True(errors.Is(err, ErrInvalidPredicate))
+			}
+		})
+	}
+}
+
+func TestValidateSelect(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	is.NoErr(ValidateSelect(`  select o.ID, c.NAME from ORDERS o JOIN CUSTOMERS c ON c.ID = o.CUSTOMER_ID`))
+	is.True(errors.Is(ValidateSelect("WITH T AS (SELECT 1 FROM X) SELECT * FROM T"), ErrInvalidPredicate))
+	is.True(errors.Is(ValidateSelect("DELETE FROM ORDERS"), ErrInvalidPredicate))
+	is.True(errors.Is(ValidateSelect("SELECT * FROM ORDERS; DELETE FROM ORDERS"), ErrInvalidPredicate))
+}
+
+func TestQualifyColumns(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	got, err := QualifyColumns(`status IN ('status', "Status") AND T.STATUS = COALESCE(Status, 0)`, "rw",
+		[]string{"STATUS", "Status"})
+	is.NoErr(err)
+	is.Equal(got, `rw."STATUS" IN ('status', rw."Status") AND T.STATUS = COALESCE(rw."STATUS", 0)`)
+
+	_, err = QualifyColumns("STATUS = 'open", "rw", []string{"STATUS"})
+	is.True(errors.Is(err, ErrInvalidPredicate))
+}
@@ -0,0 +1,77 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// Retry holds the settings of retrying operations failed with transient DB2 errors, see [IsTransient].
+type Retry struct {
+	// MaxRetries is a maximum number of retries of an operation, 0 disables retries.
+	MaxRetries int
+	// Backoff is the time before the first retry, it is doubled for every next retry.
+	Backoff time.Duration
+	// MaxBackoff is the maximum time between retries.
+	MaxBackoff time.Duration
+	// Reconnect is called before a retry of an operation failed with a communication error, see [IsCommunication].
+	Reconnect func(ctx context.Context) error
+	// SkipCompletionUnknown doesn't retry operations whose completion is unknown, see [IsCompletionUnknown],
+	// which is set for writes that would be applied twice if they were retried after they were committed.
+	SkipCompletionUnknown bool
+}
+
+// Do runs the operation and retries it with an exponential backoff while it fails with transient errors.
+// It returns the error of the last attempt.
+func (r Retry) Do(ctx context.Context, operation func() error) error {
+	err := operation()
+	backoff := r.Backoff
+
+	for retry := 1; retry <= r.MaxRetries && r.retryable(err); retry++ {
+		sdk.Logger(ctx).Warn().Err(err).
+			Int("retry", retry).
+			Dur("backoff", backoff).
+			Msg("retrying the operation failed with a transient error")
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait for retry: %w", ctx.Err())
+		case <-time.After(backoff):
+		}
+
+		if r.Reconnect != nil && IsCommunication(err) {
+			if err = r.Reconnect(ctx); err != nil {
+				err = fmt.Errorf("reconnect: %w", err)
+
+				continue
+			}
+		}
+
+		err = operation()
+
+		backoff = min(2*backoff, r.MaxBackoff)
+	}
+
+	return err
+}
+
+// retryable returns true if the operation failed with the error can be retried.
+func (r Retry) retryable(err error) bool {
+	return IsTransient(err) && !(r.SkipCompletionUnknown && IsCompletionUnknown(err))
+}
@@ -0,0 +1,127 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+var (
+	errDeadlock      = errors.New("SQL0911N  The current transaction has been rolled back.  SQLSTATE=40001")
+	errCommunication = errors.New("SQL30081N  A communication error has been detected.  SQLSTATE=08001")
+	errUnknown       = errors.New("SQL30081N  A communication error has been detected.  SQLSTATE=40003")
+	errDuplicateKey  = errors.New("SQL0803N  One or more values in the INSERT statement are not valid.  SQLSTATE=23505")
+)
+
+func TestRetry_Do(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		errs           []error
+		maxRetries     int
+		skipUnknown    bool
+		wantErr        error
+		wantCalls      int
+		wantReconnects int
+	}{
+		{
+			name:       "success",
+			errs:       []error{nil},
+			maxRetries: 3,
+			wantCalls:  1,
+		},
+		{
+			name:       "retry deadlock",
+			errs:       []error{errDeadlock, errDeadlock, nil},
+			maxRetries: 3,
+			wantCalls:  3,
+		},
+		{
+			name:           "reconnect on communication error",
+			errs:           []error{errCommunication, nil},
+			maxRetries:     3,
+			wantCalls:      2,
+			wantReconnects: 1,
+		},
+		{
+			name:           "retry unknown completion",
+			errs:           []error{errUnknown, nil},
+			maxRetries:     3,
+			wantCalls:      2,
+			wantReconnects: 1,
+		},
+		{
+			name:        "skip unknown completion",
+			errs:        []error{errUnknown},
+			maxRetries:  3,
+			skipUnknown: true,
+			wantErr:     errUnknown,
+			wantCalls:   1,
+		},
+		{
+			name:       "retries exhausted",
+			errs:       []error{errDeadlock, errDeadlock, errDeadlock},
+			maxRetries: 2,
+			wantErr:    errDeadlock,
+			wantCalls:  3,
+		},
+		{
+			name:       "permanent error",
+			errs:       []error{errDuplicateKey},
+			maxRetries: 3,
+			wantErr:    errDuplicateKey,
+			wantCalls:  1,
+		},
+		{
+			name:      "retries disabled",
+			errs:      []error{errDeadlock},
+			wantErr:   errDeadlock,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			var calls, reconnects int
+
+			retry := Retry{
+				MaxRetries:            tt.maxRetries,
+				SkipCompletionUnknown: tt.skipUnknown,
+				Reconnect: func(context.Context) error {
+					reconnects++
+
+					return nil
+				},
+			}
+
+			err := retry.Do(context.Background(), func() error {
+				calls++
+
+				return tt.errs[calls-1]
+			})
+			is.Equal(err, tt.wantErr)
+			is.Equal(calls, tt.wantCalls)
+			is.Equal(reconnects, tt.wantReconnects)
+		})
+	}
+}
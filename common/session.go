@@ -0,0 +1,106 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+// DriverName is the name the DB2 driver is registered with.
+const DriverName = "go_ibm_db"
+
+// Open opens the DB2 database of the connection string. The statements are executed on every new connection
+// of the pool before it is used, so special registers of the session, e.g. CURRENT LOCK TIMEOUT,
+// are set on all connections.
+func Open(connection string, statements ...string) (*sql.DB, error) {
+	// sql.Open doesn't connect, it only looks up the registered driver.
+	db, err := sql.Open(DriverName, connection)
+	if err != nil || len(statements) == 0 {
+		return db, err //nolint:wrapcheck // the error of sql.Open is returned as is
+	}
+
+	drv := db.Driver()
+	if err = db.Close(); err != nil {
+		return nil, fmt.Errorf("close db: %w", err)
+	}
+
+	return sql.OpenDB(newSessionConnector(drv, connection, statements)), nil
+}
+
+// sessionConnector opens connections with the driver and prepares their sessions with the statements.
+type sessionConnector struct {
+	driver     driver.Driver
+	connection string
+	statements []string
+}
+
+func newSessionConnector(drv driver.Driver, connection string, statements []string) *sessionConnector {
+	return &sessionConnector{
+		driver:     drv,
+		connection: connection,
+		statements: statements,
+	}
+}
+
+// Connect opens a connection and executes the session statements on it.
+func (c *sessionConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.connection)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // the error of the driver is returned as is
+	}
+
+	for _, statement := range c.statements {
+		if err = execSession(ctx, conn, statement); err != nil {
+			conn.Close() //nolint:errcheck // the error of the statement is returned
+
+			return nil, fmt.Errorf("exec %q: %w", statement, err)
+		}
+	}
+
+	return conn, nil
+}
+
+// Driver returns the underlying driver.
+func (c *sessionConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// execSession executes the statement without arguments on the driver connection.
+func execSession(ctx context.Context, conn driver.Conn, statement string) error {
+	if execer, ok := conn.(driver.ExecerContext); ok {
+		_, err := execer.ExecContext(ctx, statement, nil)
+		if !errors.Is(err, driver.ErrSkip) {
+			return err //nolint:wrapcheck // wrapped by the caller
+		}
+	}
+
+	stmt, err := conn.Prepare(statement)
+	if err != nil {
+		return fmt.Errorf("prepare: %w", err)
+	}
+	defer stmt.Close()
+
+	if execer, ok := stmt.(driver.StmtExecContext); ok {
+		_, err = execer.ExecContext(ctx, nil)
+	} else {
+		_, err = stmt.Exec(nil) //nolint:staticcheck // the driver doesn't implement driver.StmtExecContext
+	}
+
+	return err //nolint:wrapcheck // wrapped by the caller
+}
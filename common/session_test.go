@@ -0,0 +1,80 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+var errLockTimeout = errors.New("lock timeout")
+
+type fakeDriver struct {
+	executed []string
+	failOn   string
+	closed   bool
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) {
+	return &fakeConn{driver: d}, nil
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if query == c.driver.failOn {
+		return nil, errLockTimeout
+	}
+
+	c.driver.executed = append(c.driver.executed, query)
+
+	return driver.ResultNoRows, nil
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *fakeConn) Close() error                        { c.driver.closed = true; return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func TestSessionConnector_Connect(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	drv := &fakeDriver{}
+	connector := newSessionConnector(drv, "DATABASE=testdb", []string{"SET CURRENT LOCK TIMEOUT 10"})
+
+	conn, err := connector.Connect(context.Background())
+	is.NoErr(err)
+	is.True(conn != nil)
+	is.Equal(drv.executed, []string{"SET CURRENT LOCK TIMEOUT 10"})
+	is.Equal(connector.Driver(), drv)
+}
+
+func TestSessionConnector_Connect_Fail(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	drv := &fakeDriver{failOn: "SET CURRENT LOCK TIMEOUT 10"}
+	connector := newSessionConnector(drv, "DATABASE=testdb", []string{"SET CURRENT LOCK TIMEOUT 10"})
+
+	_, err := connector.Connect(context.Background())
+	is.True(errors.Is(err, errLockTimeout))
+	is.True(drv.closed)
+}
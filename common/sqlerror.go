@@ -0,0 +1,160 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SQLStateUndefinedColumn is a SQLSTATE of statements referencing a column which doesn't exist.
+const SQLStateUndefinedColumn = "42703"
+
+// SQLStateUndefinedTable is a SQLSTATE of statements referencing a table which doesn't exist.
+const SQLStateUndefinedTable = "42704"
+
+const (
+	// sqlStateClassConnection is a SQLSTATE class of connection exceptions.
+	sqlStateClassConnection = "08"
+	// sqlStateRollback is a SQLSTATE of the unit of work rolled back because of a deadlock or a lock timeout.
+	sqlStateRollback = "40001"
+	// sqlStateCompletionUnknown is a SQLSTATE of statements whose completion is unknown
+	// because the connection was lost.
+	sqlStateCompletionUnknown = "40003"
+	// sqlStateLockTimeout is a SQLSTATE of statements failed because of a deadlock or a lock timeout
+	// without a rollback of the unit of work.
+	sqlStateLockTimeout = "57033"
+)
+
+// communicationSQLCodes are SQLCODEs of communication failures, which can be reported without a SQLSTATE
+// of the connection exception class.
+var communicationSQLCodes = []int{-30080, -30081, -30108}
+
+var (
+	// sqlStateRegexp matches the SQLSTATE in DB2 error messages.
+	sqlStateRegexp = regexp.MustCompile(`SQLSTATE=([0-9A-Z]{5})`)
+	// sqlCodeRegexp matches the message identifier of the SQLCODE in DB2 error messages, e.g. SQL0911N.
+	sqlCodeRegexp = regexp.MustCompile(`\bSQL([0-9]{4,5})N\b`)
+)
+
+// SQLError is a DB2 error with its SQLCODE and SQLSTATE.
+type SQLError struct {
+	// Code is the SQLCODE of the error, which is negative, or 0 if it is unknown.
+	Code int
+	// State is the SQLSTATE of the error, or an empty string if it is unknown.
+	State string
+
+	err error
+}
+
+// ParseSQLError returns the SQLCODE and SQLSTATE of a go_ibm_db error, or of an error with the DB2 message.
+// Diagnostic records of go_ibm_db errors are only read in cgo builds, see driverDiagnostics.
+// It returns false if the error has neither of them.
+func ParseSQLError(err error) (*SQLError, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	sqlErr := &SQLError{err: err}
+	sqlErr.State, sqlErr.Code = driverDiagnostics(err)
+
+	message := err.Error()
+
+	if sqlErr.State == "" {
+		if matches := sqlStateRegexp.FindStringSubmatch(message); matches != nil {
+			sqlErr.State = matches[1]
+		}
+	}
+
+	if sqlErr.Code == 0 {
+		if matches := sqlCodeRegexp.FindStringSubmatch(message); matches != nil {
+			code, _ := strconv.Atoi(matches[1]) //nolint:errcheck // the regexp matches digits only
+			sqlErr.Code = -code
+		}
+	}
+
+	if sqlErr.State == "" && sqlErr.Code == 0 {
+		return nil, false
+	}
+
+	return sqlErr, true
+}
+
+// Error returns the message of the DB2 error.
+func (e *SQLError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *SQLError) Unwrap() error {
+	return e.err
+}
+
+// Communication returns true if the connection to the DB2 server failed.
+func (e *SQLError) Communication() bool {
+	return strings.HasPrefix(e.State, sqlStateClassConnection) ||
+		e.State == sqlStateCompletionUnknown ||
+		slices.Contains(communicationSQLCodes, e.Code)
+}
+
+// Transient returns true if the statement can succeed when it is executed again: the unit of work was rolled back
+// because of a deadlock or a lock timeout, the statement timed out waiting for a lock, or the connection failed.
+func (e *SQLError) Transient() bool {
+	return e.State == sqlStateRollback || e.State == sqlStateLockTimeout || e.Communication()
+}
+
+// SQLState returns the SQLSTATE of a DB2 error, or an empty string if the error doesn't have it.
+func SQLState(err error) string {
+	if sqlErr, ok := ParseSQLError(err); ok {
+		return sqlErr.State
+	}
+
+	return ""
+}
+
+// IsTransient returns true if the error is a transient DB2 error, see [SQLError.Transient],
+// or the driver reported a broken connection.
+func IsTransient(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+
+	sqlErr, ok := ParseSQLError(err)
+
+	return ok && sqlErr.Transient()
+}
+
+// IsCompletionUnknown returns true if the connection was lost while the statement was executed,
+// so it is unknown whether its unit of work was committed.
+func IsCompletionUnknown(err error) bool {
+	sqlErr, ok := ParseSQLError(err)
+
+	return ok && sqlErr.State == sqlStateCompletionUnknown
+}
+
+// IsCommunication returns true if the error is a failure of the connection to the DB2 server.
+func IsCommunication(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+
+	sqlErr, ok := ParseSQLError(err)
+
+	return ok && sqlErr.Communication()
+}
@@ -0,0 +1,47 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package common
+
+import (
+	"errors"
+
+	"github.com/ibmdb/go_ibm_db"
+)
+
+// driverDiagnostics returns the first SQLSTATE and SQLCODE of the diagnostic records of a go_ibm_db error,
+// or an empty SQLSTATE and 0 if the error is not a go_ibm_db error.
+func driverDiagnostics(err error) (string, int) {
+	var (
+		state string
+		code  int
+	)
+
+	var dbErr *go_ibm_db.Error
+	if errors.As(err, &dbErr) {
+		for _, diag := range dbErr.Diag {
+			if state == "" {
+				state = diag.State
+			}
+
+			if code == 0 && diag.NativeError < 0 {
+				code = diag.NativeError
+			}
+		}
+	}
+
+	return state, code
+}
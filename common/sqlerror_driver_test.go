@@ -0,0 +1,46 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package common
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ibmdb/go_ibm_db"
+	"github.com/matryer/is"
+)
+
+func TestParseSQLError_Driver(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	sqlErr, ok := ParseSQLError(fmt.Errorf("exec insert: %w", &go_ibm_db.Error{
+		APIName: "SQLExecute",
+		Diag:    []go_ibm_db.DiagRecord{{State: "42703", NativeError: -206}},
+	}))
+	is.True(ok)
+	is.Equal(sqlErr.Code, -206)
+	is.Equal(sqlErr.State, SQLStateUndefinedColumn)
+
+	sqlErr, ok = ParseSQLError(&go_ibm_db.Error{
+		APIName: "SQLExecute",
+		Diag:    []go_ibm_db.DiagRecord{{State: "08001", NativeError: -30081}},
+	})
+	is.True(ok)
+	is.True(sqlErr.Transient())
+	is.True(sqlErr.Communication())
+}
@@ -0,0 +1,23 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cgo
+
+package common
+
+// driverDiagnostics returns an empty SQLSTATE and 0. The go_ibm_db driver requires cgo, so without it errors
+// can't be go_ibm_db errors, and DB2 errors are parsed from their messages.
+func driverDiagnostics(error) (string, int) {
+	return "", 0
+}
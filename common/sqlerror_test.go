@@ -0,0 +1,108 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestSQLState(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	is.Equal(SQLState(errors.New(`SQL0206N  "EMAIL" is not valid in the context where it is used.  SQLSTATE=42703`)),
+		SQLStateUndefinedColumn)
+	is.Equal(SQLState(errors.New(`SQL0204N  "DB2INST1.USERS" is an undefined name.  SQLSTATE=42704`)),
+		SQLStateUndefinedTable)
+	is.Equal(SQLState(errors.New("connection refused")), "")
+	is.Equal(SQLState(nil), "")
+}
+
+func TestParseSQLError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantState     string
+		transient     bool
+		communication bool
+	}{
+		{
+			name: "deadlock",
+			err: errors.New(`SQL0911N  The current transaction has been rolled back because of a deadlock or timeout.  ` +
+				`SQLSTATE=40001`),
+			wantCode:  -911,
+			wantState: "40001",
+			transient: true,
+		},
+		{
+			name:      "lock timeout message",
+			err:       errors.New(`SQL0913N  Unsuccessful execution caused by deadlock or timeout.  SQLSTATE=57033`),
+			wantCode:  -913,
+			wantState: "57033",
+			transient: true,
+		},
+		{
+			name: "communication error",
+			err: fmt.Errorf("load rows: %w",
+				errors.New(`SQL30081N  A communication error has been detected.  SQLSTATE=08001`)),
+			wantCode:      -30081,
+			wantState:     "08001",
+			transient:     true,
+			communication: true,
+		},
+		{
+			name:      "undefined column",
+			err:       errors.New(`SQL0206N  "EMAIL" is not valid in the context where it is used.  SQLSTATE=42703`),
+			wantCode:  -206,
+			wantState: SQLStateUndefinedColumn,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			sqlErr, ok := ParseSQLError(tt.err)
+			is.True(ok)
+			is.Equal(sqlErr.Code, tt.wantCode)
+			is.Equal(sqlErr.State, tt.wantState)
+			is.Equal(IsTransient(tt.err), tt.transient)
+			is.Equal(IsCommunication(tt.err), tt.communication)
+		})
+	}
+}
+
+func TestParseSQLError_NotDB2(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	_, ok := ParseSQLError(errors.New("connection refused"))
+	is.True(!ok)
+
+	_, ok = ParseSQLError(nil)
+	is.True(!ok)
+
+	is.True(IsTransient(fmt.Errorf("query: %w", driver.ErrBadConn)))
+	is.True(IsCommunication(driver.ErrBadConn))
+}
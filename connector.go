@@ -15,6 +15,7 @@
 package db2
 
 import (
+	"github.com/conduitio-labs/conduit-connector-db2/common"
 	"github.com/conduitio-labs/conduit-connector-db2/destination"
 	"github.com/conduitio-labs/conduit-connector-db2/source"
 	sdk "github.com/conduitio/conduit-connector-sdk"
@@ -25,3 +26,8 @@ var Connector = sdk.Connector{
 	NewSource:        source.NewSource,
 	NewDestination:   destination.NewDestination,
 }
+
+func init() {
+	// the version is set in this package during the build, the connectors report it in the client information.
+	common.ConnectorVersion = version
+}
@@ -0,0 +1,321 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate paramgen -output=paramgen.go Config
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/conduitio-labs/conduit-connector-db2/common"
+	"github.com/conduitio-labs/conduit-connector-db2/destination/writer"
+)
+
+// Config holds destination specific configurable values.
+type Config struct {
+	common.Configuration
+
+	// BinaryEncoding is an encoding of string values written to binary columns: "raw" (bytes of the string),
+	// "base64" (standard base64, the way JSON encodes binary data) or "hex".
+	BinaryEncoding string `json:"binaryEncoding" default:"raw" validate:"inclusion=raw|base64|hex"`
+	// LOBMaxBytes is a maximum size of CLOB, BLOB and DBCLOB values in bytes, zero means no limit.
+	// Larger values are rejected before they are decoded.
+	LOBMaxBytes int `json:"lobMaxBytes" default:"0" validate:"gt=-1"`
+	// AutoCreateTable creates a missing table on the first record written to it. Column types are derived from
+	// the record's OpenCDC schema or inferred from the payload, the primary key is created from the record key.
+	AutoCreateTable bool `json:"autoCreateTable" default:"false"`
+	// TypeMapping overrides DB2 column types of created tables by value kind: "boolean", "int", "long", "float",
+	// "double", "number", "string", "keyString", "bytes", "date", "time", "timestamp" or "json".
+	TypeMapping map[string]string `json:"typeMapping"`
+	// SchemaEvolution is a way of handling payload fields which are not columns of the table:
+	// "strict" (fail the record), "evolve" (add nullable columns) or "ignore" (drop the fields).
+	SchemaEvolution string `json:"schemaEvolution" default:"strict" validate:"inclusion=strict|evolve|ignore"`
+	// WidenVarchar increases lengths of VARCHAR columns which are too short for the payload values.
+	WidenVarchar bool `json:"widenVarchar" default:"false"`
+	// ColumnMapping renames payload and key fields to columns, e.g. "columnMapping.userId: USER_ID".
+	ColumnMapping map[string]string `json:"columnMapping"`
+	// ColumnCase is a strategy of deriving column names of fields which are not renamed:
+	// "none" (field names as is), "upper" (uppercase) or "snakeUpper" (e.g. "userId" becomes "USER_ID").
+	ColumnCase string `json:"columnCase" default:"none" validate:"inclusion=none|upper|snakeUpper"`
+	// IncludeFields is a comma separated list of payload fields which are written, by default all fields are written.
+	IncludeFields []string `json:"includeFields"`
+	// ExcludeFields is a comma separated list of payload fields which are not written.
+	ExcludeFields []string `json:"excludeFields"`
+	// WriteMode is a way of writing records: "standard" (insert, update and delete rows),
+	// "softDelete" (mark deleted rows in the softDeleteColumn), "appendOnly" (insert every record as a new row
+	// with its operation, position and timestamp) or "scd2" (keep the history of rows with validity columns).
+	WriteMode string `json:"writeMode" default:"standard" validate:"inclusion=standard|softDelete|appendOnly|scd2"`
+	// SoftDeleteColumn is a name of the column marking deleted rows in the softDelete write mode.
+	SoftDeleteColumn string `json:"softDeleteColumn" default:"DELETED_AT"`
+	// SoftDeleteFlag sets the softDeleteColumn to 1 instead of the deletion timestamp.
+	SoftDeleteFlag bool `json:"softDeleteFlag" default:"false"`
+	// OperationColumn is a name of the column with the operation of the record in the appendOnly write mode.
+	OperationColumn string `json:"operationColumn" default:"CONDUIT_OPERATION"`
+	// PositionColumn is a name of the column with the position of the record in the appendOnly write mode.
+	PositionColumn string `json:"positionColumn" default:"CONDUIT_POSITION"`
+	// TimestampColumn is a name of the column with the time the record was read at in the appendOnly write mode.
+	TimestampColumn string `json:"timestampColumn" default:"CONDUIT_TIMESTAMP"`
+	// ValidFromColumn is a name of the column with the time the row became current in the scd2 write mode.
+	ValidFromColumn string `json:"validFromColumn" default:"VALID_FROM"`
+	// ValidToColumn is a name of the column with the time the row was closed in the scd2 write mode.
+	ValidToColumn string `json:"validToColumn" default:"VALID_TO"`
+	// CurrentColumn is a name of the column which is 1 for the current row and 0 for closed rows
+	// in the scd2 write mode.
+	CurrentColumn string `json:"currentColumn" default:"IS_CURRENT"`
+	// CheckpointTable is a name of the table storing the position of the last applied record per pipeline and table,
+	// which is updated in the transaction of the write. Records at or before the checkpoint are skipped.
+	// The table is created if it doesn't exist, checkpoints are disabled if it is empty.
+	CheckpointTable string `json:"checkpointTable"`
+	// CheckpointPipeline identifies the pipeline in the checkpoint table.
+	// By default, the source connector ID from the record metadata is used.
+	CheckpointPipeline string `json:"checkpointPipeline"`
+	// VersionColumn is a column which value must increase for updates to be applied. Updates with a version
+	// which is not greater than the stored one are rejected, counted and logged instead of failing.
+	VersionColumn string `json:"versionColumn"`
+	// BulkMode writes every batch of records through a staging table and applies it to the target table
+	// with a single MERGE and a single DELETE statement in a transaction. Only the last record of every key
+	// in the batch is applied, and columns missing in the payload of a record are written as NULL.
+	BulkMode bool `json:"bulkMode" default:"false"`
+	// BulkStaging is a kind of the staging table: "temporary" (a declared global temporary table)
+	// or "table" (a regular table with the "CONDUIT_STAGE_" prefix, which is created if it doesn't exist).
+	BulkStaging string `json:"bulkStaging" default:"temporary" validate:"inclusion=temporary|table"`
+	// BulkLoadMethod is a way of loading batches into the staging table: "insert" (multi-row INSERT statements)
+	// or "load" (the LOAD utility called through SYSPROC.ADMIN_CMD, requires the "table" staging).
+	BulkLoadMethod string `json:"bulkLoadMethod" default:"insert" validate:"inclusion=insert|load"`
+	// BulkRows is a maximum number of rows loaded into the staging table by a single statement.
+	BulkRows int `json:"bulkRows" default:"100" validate:"gt=0"`
+	// ReloadMode is a way of removing stale rows when a new snapshot starts: "none" (snapshot records
+	// are written over the existing rows), "truncate" (the table is truncated on the first snapshot record,
+	// readers see it partially loaded) or "swap" (the snapshot is loaded into a shadow table, which replaces
+	// the table when the snapshot ends). "truncate" and "swap" require checkpointTable.
+	ReloadMode string `json:"reloadMode" default:"none" validate:"inclusion=none|truncate|swap"`
+}
+
+// Init initializes common configuration.
+// Table templates are case-sensitive, so they are kept as is and the rendered table names are uppercased.
+func (c Config) Init() Config {
+	table := c.Table
+
+	c.Configuration = c.Configuration.Init()
+
+	if writer.IsTableTemplate(table) {
+		c.Table = table
+	}
+
+	return c
+}
+
+// Validate executes manual validations beyond what is defined in struct tags.
+func (c *Config) Validate() error {
+	configuration := c.Configuration
+
+	// Validate the table template, the length limit applies to the rendered table names.
+	if writer.IsTableTemplate(c.Table) {
+		if _, err := writer.ParseTableTemplate(c.Table); err != nil {
+			return err
+		}
+
+		configuration.Table = ""
+	}
+
+	// Validate common configuration.
+	err := configuration.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Validate TypeMapping.
+	for kind, dataType := range c.TypeMapping {
+		if _, ok := writer.DefaultTypeMapping[kind]; !ok {
+			return fmt.Errorf("unknown typeMapping kind %q", kind)
+		}
+
+		if strings.TrimSpace(dataType) == "" {
+			return fmt.Errorf("typeMapping of kind %q must not be empty", kind)
+		}
+	}
+
+	// Validate ColumnMapping.
+	for field, column := range c.ColumnMapping {
+		if strings.TrimSpace(column) == "" {
+			return fmt.Errorf("columnMapping of field %q must not be empty", field)
+		}
+	}
+
+	// Validate CheckpointTable.
+	if len(c.CheckpointTable) > common.MaxConfigStringLength {
+		return common.NewLessThanError(ConfigCheckpointTable, common.MaxConfigStringLength)
+	}
+
+	// Validate VersionColumn.
+	if len(c.VersionColumn) > common.MaxConfigStringLength {
+		return common.NewLessThanError(ConfigVersionColumn, common.MaxConfigStringLength)
+	}
+
+	// Validate bulk mode.
+	if c.BulkMode {
+		if writer.WriteMode(c.WriteMode) != writer.WriteModeStandard {
+			return fmt.Errorf("%q requires the %q %q", ConfigBulkMode, writer.WriteModeStandard, ConfigWriteMode)
+		}
+
+		if writer.LoadMethod(c.BulkLoadMethod) == writer.LoadMethodLoad &&
+			writer.Staging(c.BulkStaging) != writer.StagingTable {
+			return fmt.Errorf("%q %q requires the %q %q",
+				ConfigBulkLoadMethod, writer.LoadMethodLoad, writer.StagingTable, ConfigBulkStaging)
+		}
+
+		// the LOAD utility is called through SYSPROC.ADMIN_CMD.
+		if writer.LoadMethod(c.BulkLoadMethod) == writer.LoadMethodLoad {
+			if err := c.Dialect().ValidateFeature(common.FeatureAdminCmd); err != nil {
+				return fmt.Errorf("%q %q: %w", ConfigBulkLoadMethod, writer.LoadMethodLoad, err)
+			}
+		}
+	}
+
+	// Validate reload mode, tables with the history of rows are never reloaded.
+	if writer.ReloadMode(c.ReloadMode) != writer.ReloadModeNone {
+		switch writer.WriteMode(c.WriteMode) {
+		case writer.WriteModeAppendOnly, writer.WriteModeSCD2:
+			return fmt.Errorf("%q %q doesn't support the %q %q",
+				ConfigWriteMode, c.WriteMode, ConfigReloadMode, c.ReloadMode)
+		}
+
+		// checkpoints tell a resumed snapshot from a new one, which starts the reload again.
+		if c.CheckpointTable == "" {
+			return fmt.Errorf("%q %q requires %q", ConfigReloadMode, c.ReloadMode, ConfigCheckpointTable)
+		}
+	}
+
+	// Validate columns of the write mode.
+	switch writer.WriteMode(c.WriteMode) {
+	case writer.WriteModeSoftDelete:
+		if err = validateColumn(ConfigSoftDeleteColumn, c.SoftDeleteColumn); err != nil {
+			return err
+		}
+
+	case writer.WriteModeAppendOnly:
+		if err = validateColumns(map[string]string{
+			ConfigOperationColumn: c.OperationColumn,
+			ConfigPositionColumn:  c.PositionColumn,
+			ConfigTimestampColumn: c.TimestampColumn,
+		}); err != nil {
+			return err
+		}
+
+	case writer.WriteModeSCD2:
+		if err = validateColumns(map[string]string{
+			ConfigValidFromColumn: c.ValidFromColumn,
+			ConfigValidToColumn:   c.ValidToColumn,
+			ConfigCurrentColumn:   c.CurrentColumn,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateColumns validates the columns of the parameters, which must be different.
+func validateColumns(columns map[string]string) error {
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for i, name := range names {
+		if err := validateColumn(name, columns[name]); err != nil {
+			return err
+		}
+
+		for _, other := range names[:i] {
+			if strings.EqualFold(columns[name], columns[other]) {
+				return fmt.Errorf("%q and %q must be different columns", other, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateColumn returns an error if the column of the parameter is empty or too long.
+func validateColumn(name, column string) error {
+	if strings.TrimSpace(column) == "" {
+		return fmt.Errorf("%q must not be empty", name)
+	}
+
+	if len(column) > common.MaxConfigStringLength {
+		return common.NewLessThanError(name, common.MaxConfigStringLength)
+	}
+
+	return nil
+}
+
+// SoftDeleteOptions returns options of the soft-delete write mode.
+func (c Config) SoftDeleteOptions() writer.SoftDeleteOptions {
+	return writer.SoftDeleteOptions{
+		Column: c.SoftDeleteColumn,
+		Flag:   c.SoftDeleteFlag,
+	}
+}
+
+// AppendColumns returns columns of the append-only write mode.
+func (c Config) AppendColumns() writer.AppendColumns {
+	return writer.AppendColumns{
+		Operation: c.OperationColumn,
+		Position:  c.PositionColumn,
+		Timestamp: c.TimestampColumn,
+	}
+}
+
+// SCD2Columns returns columns of the SCD2 write mode.
+func (c Config) SCD2Columns() writer.SCD2Columns {
+	return writer.SCD2Columns{
+		ValidFrom: c.ValidFromColumn,
+		ValidTo:   c.ValidToColumn,
+		Current:   c.CurrentColumn,
+	}
+}
+
+// Checkpoints returns options of storing positions of the applied records.
+func (c Config) Checkpoints() writer.Checkpoints {
+	return writer.Checkpoints{
+		Table:    common.NormalizeIdentifier(c.CheckpointTable),
+		Pipeline: c.CheckpointPipeline,
+	}
+}
+
+// BulkOptions returns options of writing batches through a staging table.
+func (c Config) BulkOptions() writer.BulkOptions {
+	return writer.BulkOptions{
+		Enabled:    c.BulkMode,
+		Staging:    writer.Staging(c.BulkStaging),
+		LoadMethod: writer.LoadMethod(c.BulkLoadMethod),
+		Rows:       c.BulkRows,
+	}
+}
+
+// FieldMapping returns the mapping of payload and key fields to columns.
+func (c Config) FieldMapping() writer.ColumnMapping {
+	return writer.ColumnMapping{
+		Renames: c.ColumnMapping,
+		Case:    writer.ColumnCase(c.ColumnCase),
+		Include: c.IncludeFields,
+		Exclude: c.ExcludeFields,
+	}
+}
@@ -0,0 +1,418 @@
+// Code generated by paramgen. DO NOT EDIT.
+// Source: github.com/ConduitIO/conduit-commons/tree/main/paramgen
+
+package config
+
+import (
+	"github.com/conduitio/conduit-commons/config"
+)
+
+const (
+	ConfigAutoCreateTable       = "autoCreateTable"
+	ConfigBinaryEncoding        = "binaryEncoding"
+	ConfigBulkLoadMethod        = "bulkLoadMethod"
+	ConfigBulkMode              = "bulkMode"
+	ConfigBulkRows              = "bulkRows"
+	ConfigBulkStaging           = "bulkStaging"
+	ConfigCheckpointPipeline    = "checkpointPipeline"
+	ConfigCheckpointTable       = "checkpointTable"
+	ConfigClientAccounting      = "clientAccounting"
+	ConfigClientApplName        = "clientApplName"
+	ConfigClientUserID          = "clientUserID"
+	ConfigClientWrkstnName      = "clientWrkstnName"
+	ConfigColumnCase            = "columnCase"
+	ConfigColumnMapping         = "columnMapping.*"
+	ConfigConnection            = "connection"
+	ConfigConnectionMaxLifetime = "connectionMaxLifetime"
+	ConfigCurrentColumn         = "currentColumn"
+	ConfigCurrentSchema         = "currentSchema"
+	ConfigDatabase              = "database"
+	ConfigExcludeFields         = "excludeFields"
+	ConfigHost                  = "host"
+	ConfigIncludeFields         = "includeFields"
+	ConfigLobMaxBytes           = "lobMaxBytes"
+	ConfigMaxIdleConnections    = "maxIdleConnections"
+	ConfigMaxOpenConnections    = "maxOpenConnections"
+	ConfigMaxRetries            = "maxRetries"
+	ConfigOperationColumn       = "operationColumn"
+	ConfigPassword              = "password"
+	ConfigPasswordEnv           = "passwordEnv"
+	ConfigPasswordFile          = "passwordFile"
+	ConfigPlatform              = "platform"
+	ConfigPort                  = "port"
+	ConfigPositionColumn        = "positionColumn"
+	ConfigReloadMode            = "reloadMode"
+	ConfigRetryBackoff          = "retryBackoff"
+	ConfigRetryMaxBackoff       = "retryMaxBackoff"
+	ConfigSchemaEvolution       = "schemaEvolution"
+	ConfigSoftDeleteColumn      = "softDeleteColumn"
+	ConfigSoftDeleteFlag        = "softDeleteFlag"
+	ConfigSsl                   = "ssl"
+	ConfigSslClientKeystash     = "sslClientKeystash"
+	ConfigSslClientKeystore     = "sslClientKeystore"
+	ConfigSslServerCertificate  = "sslServerCertificate"
+	ConfigTable                 = "table"
+	ConfigTimestampColumn       = "timestampColumn"
+	ConfigTimezone              = "timezone"
+	ConfigTypeMapping           = "typeMapping.*"
+	ConfigUser                  = "user"
+	ConfigValidFromColumn       = "validFromColumn"
+	ConfigValidToColumn         = "validToColumn"
+	ConfigVersionColumn         = "versionColumn"
+	ConfigWidenVarchar          = "widenVarchar"
+	ConfigWriteMode             = "writeMode"
+)
+
+func (Config) Parameters() map[string]config.Parameter {
+	return map[string]config.Parameter{
+		ConfigAutoCreateTable: {
+			Default:     "false",
+			Description: "AutoCreateTable creates a missing table on the first record written to it. Column types are derived from\nthe record's OpenCDC schema or inferred from the payload, the primary key is created from the record key.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigBinaryEncoding: {
+			Default:     "raw",
+			Description: "BinaryEncoding is an encoding of string values written to binary columns: \"raw\" (bytes of the string),\n\"base64\" (standard base64, the way JSON encodes binary data) or \"hex\".",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"raw", "base64", "hex"}},
+			},
+		},
+		ConfigBulkLoadMethod: {
+			Default:     "insert",
+			Description: "BulkLoadMethod is a way of loading batches into the staging table: \"insert\" (multi-row INSERT statements)\nor \"load\" (the LOAD utility called through SYSPROC.ADMIN_CMD, requires the \"table\" staging).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"insert", "load"}},
+			},
+		},
+		ConfigBulkMode: {
+			Default:     "false",
+			Description: "BulkMode writes every batch of records through a staging table and applies it to the target table\nwith a single MERGE and a single DELETE statement in a transaction. Only the last record of every key\nin the batch is applied, and columns missing in the payload of a record are written as NULL.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigBulkRows: {
+			Default:     "100",
+			Description: "BulkRows is a maximum number of rows loaded into the staging table by a single statement.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
+			},
+		},
+		ConfigBulkStaging: {
+			Default:     "temporary",
+			Description: "BulkStaging is a kind of the staging table: \"temporary\" (a declared global temporary table)\nor \"table\" (a regular table with the \"CONDUIT_STAGE_\" prefix, which is created if it doesn't exist).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"temporary", "table"}},
+			},
+		},
+		ConfigCheckpointPipeline: {
+			Default:     "",
+			Description: "CheckpointPipeline identifies the pipeline in the checkpoint table.\nBy default, the source connector ID from the record metadata is used.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigCheckpointTable: {
+			Default:     "",
+			Description: "CheckpointTable is a name of the table storing the position of the last applied record per pipeline and table,\nwhich is updated in the transaction of the write. Records at or before the checkpoint are skipped.\nThe table is created if it doesn't exist, checkpoints are disabled if it is empty.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigClientAccounting: {
+			Default:     "",
+			Description: "ClientAccounting is the CLIENT_ACCTNG of the sessions, by default the connector name and version.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigClientApplName: {
+			Default:     "",
+			Description: "ClientApplName is the CLIENT_APPLNAME of the sessions, by default \"conduit-connector-db2-source\"\nor \"conduit-connector-db2-destination\".",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigClientUserID: {
+			Default:     "",
+			Description: "ClientUserID is the CLIENT_USERID of the sessions, by default the pipeline ID.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigClientWrkstnName: {
+			Default:     "",
+			Description: "ClientWrkstnName is the CLIENT_WRKSTNNAME of the sessions, by default the connector ID.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigColumnCase: {
+			Default:     "none",
+			Description: "ColumnCase is a strategy of deriving column names of fields which are not renamed:\n\"none\" (field names as is), \"upper\" (uppercase) or \"snakeUpper\" (e.g. \"userId\" becomes \"USER_ID\").",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"none", "upper", "snakeUpper"}},
+			},
+		},
+		ConfigColumnMapping: {
+			Default:     "",
+			Description: "ColumnMapping renames payload and key fields to columns, e.g. \"columnMapping.userId: USER_ID\".",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigConnection: {
+			Default:     "",
+			Description: "Connection string connection to DB2 database. The connection can be configured with the \"host\", \"port\",\n\"database\", \"user\" and password fields instead.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigConnectionMaxLifetime: {
+			Default:     "30m",
+			Description: "ConnectionMaxLifetime is a maximum time a connection is reused, 0 means connections are reused forever.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigCurrentColumn: {
+			Default:     "IS_CURRENT",
+			Description: "CurrentColumn is a name of the column which is 1 for the current row and 0 for closed rows\nin the scd2 write mode.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigCurrentSchema: {
+			Default:     "",
+			Description: "CurrentSchema is the schema of unqualified table names, by default the schema of the user.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigDatabase: {
+			Default:     "",
+			Description: "Database is a name of the database.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigExcludeFields: {
+			Default:     "",
+			Description: "ExcludeFields is a comma separated list of payload fields which are not written.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigHost: {
+			Default:     "",
+			Description: "Host is a host name or an IP address of the DB2 server, it is used instead of the \"connection\" string.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigIncludeFields: {
+			Default:     "",
+			Description: "IncludeFields is a comma separated list of payload fields which are written, by default all fields are written.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigLobMaxBytes: {
+			Default:     "0",
+			Description: "LOBMaxBytes is a maximum size of CLOB, BLOB and DBCLOB values in bytes, zero means no limit.\nLarger values are rejected before they are decoded.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigMaxIdleConnections: {
+			Default:     "2",
+			Description: "MaxIdleConnections is a maximum number of idle connections kept open for reuse.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigMaxOpenConnections: {
+			Default:     "4",
+			Description: "MaxOpenConnections is a maximum number of open connections of the connector, 0 means no limit.\nThe connector uses up to two connections at the same time, so the limit must be 0 or at least 2.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigMaxRetries: {
+			Default:     "3",
+			Description: "MaxRetries is a maximum number of retries of reads and writes failed with a transient error: a deadlock,\na lock timeout or a communication failure, after which the connector reconnects. 0 disables retries.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigOperationColumn: {
+			Default:     "CONDUIT_OPERATION",
+			Description: "OperationColumn is a name of the column with the operation of the record in the appendOnly write mode.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigPassword: {
+			Default:     "",
+			Description: "Password is a password of the user.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigPasswordEnv: {
+			Default:     "",
+			Description: "PasswordEnv is a name of an environment variable with the password of the user.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigPasswordFile: {
+			Default:     "",
+			Description: "PasswordFile is a path of a file with the password of the user, a trailing line break is ignored.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigPlatform: {
+			Default:     "luw",
+			Description: "Platform is the DB2 platform of the database, which selects the SQL dialect of the queries:\n\"luw\" (DB2 for Linux, UNIX and Windows), \"zos\" (DB2 for z/OS) or \"ibmi\" (DB2 for IBM i).\nOnly \"luw\" is fully supported, options which rely on statements of other platforms are rejected.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"luw", "zos", "ibmi"}},
+			},
+		},
+		ConfigPort: {
+			Default:     "50000",
+			Description: "Port is a port of the DB2 server.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
+				config.ValidationLessThan{V: 65536},
+			},
+		},
+		ConfigPositionColumn: {
+			Default:     "CONDUIT_POSITION",
+			Description: "PositionColumn is a name of the column with the position of the record in the appendOnly write mode.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigReloadMode: {
+			Default:     "none",
+			Description: "ReloadMode is a way of removing stale rows when a new snapshot starts: \"none\" (snapshot records\nare written over the existing rows), \"truncate\" (the table is truncated on the first snapshot record,\nreaders see it partially loaded) or \"swap\" (the snapshot is loaded into a shadow table, which replaces\nthe table when the snapshot ends). \"truncate\" and \"swap\" require checkpointTable.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"none", "truncate", "swap"}},
+			},
+		},
+		ConfigRetryBackoff: {
+			Default:     "1s",
+			Description: "RetryBackoff is the time before the first retry, it is doubled for every next retry.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigRetryMaxBackoff: {
+			Default:     "30s",
+			Description: "RetryMaxBackoff is the maximum time between retries.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigSchemaEvolution: {
+			Default:     "strict",
+			Description: "SchemaEvolution is a way of handling payload fields which are not columns of the table:\n\"strict\" (fail the record), \"evolve\" (add nullable columns) or \"ignore\" (drop the fields).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"strict", "evolve", "ignore"}},
+			},
+		},
+		ConfigSoftDeleteColumn: {
+			Default:     "DELETED_AT",
+			Description: "SoftDeleteColumn is a name of the column marking deleted rows in the softDelete write mode.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigSoftDeleteFlag: {
+			Default:     "false",
+			Description: "SoftDeleteFlag sets the softDeleteColumn to 1 instead of the deletion timestamp.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigSsl: {
+			Default:     "",
+			Description: "SSL enables TLS connections to the server (SECURITY=SSL).",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigSslClientKeystash: {
+			Default:     "",
+			Description: "SSLClientKeystash is a path of the stash file (.sth) with the password of the client key database.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigSslClientKeystore: {
+			Default:     "",
+			Description: "SSLClientKeystore is a path of the key database (.kdb) with the client certificate\nand trusted certificates.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigSslServerCertificate: {
+			Default:     "",
+			Description: "SSLServerCertificate is a path of the certificate of the server or of the CA that signed it.\nBy default, the certificates of the client key database are trusted.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigTable: {
+			Default:     "",
+			Description: "Table is a name of the table that the connector should write to or read from.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationRequired{},
+			},
+		},
+		ConfigTimestampColumn: {
+			Default:     "CONDUIT_TIMESTAMP",
+			Description: "TimestampColumn is a name of the column with the time the record was read at in the appendOnly write mode.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigTimezone: {
+			Default:     "",
+			Description: "Timezone is a time zone of the DB2 session, which is used to interpret DATE, TIME and TIMESTAMP values\nwithout time zone, e.g. \"UTC\" or \"Europe/Berlin\". By default, the local time zone of the connector is used.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigTypeMapping: {
+			Default:     "",
+			Description: "TypeMapping overrides DB2 column types of created tables by value kind: \"boolean\", \"int\", \"long\", \"float\",\n\"double\", \"number\", \"string\", \"keyString\", \"bytes\", \"date\", \"time\", \"timestamp\" or \"json\".",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigUser: {
+			Default:     "",
+			Description: "User is a name of the user the connector connects as.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigValidFromColumn: {
+			Default:     "VALID_FROM",
+			Description: "ValidFromColumn is a name of the column with the time the row became current in the scd2 write mode.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigValidToColumn: {
+			Default:     "VALID_TO",
+			Description: "ValidToColumn is a name of the column with the time the row was closed in the scd2 write mode.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigVersionColumn: {
+			Default:     "",
+			Description: "VersionColumn is a column which value must increase for updates to be applied. Updates with a version\nwhich is not greater than the stored one are rejected, counted and logged instead of failing.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigWidenVarchar: {
+			Default:     "false",
+			Description: "WidenVarchar increases lengths of VARCHAR columns which are too short for the payload values.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigWriteMode: {
+			Default:     "standard",
+			Description: "WriteMode is a way of writing records: \"standard\" (insert, update and delete rows),\n\"softDelete\" (mark deleted rows in the softDeleteColumn), \"appendOnly\" (insert every record as a new row\nwith its operation, position and timestamp) or \"scd2\" (keep the history of rows with validity columns).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"standard", "softDelete", "appendOnly", "scd2"}},
+			},
+		},
+	}
+}
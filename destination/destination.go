@@ -19,7 +19,9 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/conduitio-labs/conduit-connector-db2/coltypes"
 	"github.com/conduitio-labs/conduit-connector-db2/common"
+	"github.com/conduitio-labs/conduit-connector-db2/destination/config"
 	"github.com/conduitio-labs/conduit-connector-db2/destination/writer"
 	commonsConfig "github.com/conduitio/conduit-commons/config"
 	"github.com/conduitio/conduit-commons/opencdc"
@@ -34,7 +36,11 @@ type Destination struct {
 	sdk.UnimplementedDestination
 
 	writer Writer
-	config common.Configuration
+	config config.Config
+	// db is the connection pool of the writer, it is closed on teardown.
+	db *sql.DB
+	// retry retries writes failed with transient errors.
+	retry common.Retry
 }
 
 // NewDestination creates new instance of the Destination.
@@ -66,18 +72,44 @@ func (d *Destination) Configure(ctx context.Context, cfg commonsConfig.Config) e
 
 // Open makes sure everything is prepared to receive records.
 func (d *Destination) Open(ctx context.Context) error {
-	db, err := sql.Open("go_ibm_db", d.config.Connection)
+	loc, err := d.config.Location()
 	if err != nil {
-		return fmt.Errorf("connect to db2: %w", err)
+		return fmt.Errorf("get location: %w", err)
 	}
 
-	if err = db.PingContext(ctx); err != nil {
-		return fmt.Errorf("ping db2: %w", err)
+	conn, err := d.config.ConnectionString(ctx, common.RoleDestination)
+	if err != nil {
+		return fmt.Errorf("connection string: %w", err)
 	}
 
+	d.db, err = common.Connect(ctx, conn, d.config.Pool())
+	if err != nil {
+		return fmt.Errorf("connect to db2: %w", err)
+	}
+
+	d.retry = d.config.Retry(d.db)
+	// a write whose completion is unknown may be committed, and only checkpoints skip it when it is retried.
+	d.retry.SkipCompletionUnknown = d.config.CheckpointTable == ""
+
 	d.writer, err = writer.NewWriter(ctx, writer.Params{
-		DB:    db,
-		Table: d.config.Table,
+		DB:              d.db,
+		Table:           d.config.Table,
+		Location:        loc,
+		BinaryEncoding:  coltypes.BinaryEncoding(d.config.BinaryEncoding),
+		LOBMaxBytes:     d.config.LOBMaxBytes,
+		AutoCreateTable: d.config.AutoCreateTable,
+		TypeMapping:     d.config.TypeMapping,
+		SchemaEvolution: writer.SchemaEvolution(d.config.SchemaEvolution),
+		WidenVarchar:    d.config.WidenVarchar,
+		ColumnMapping:   d.config.FieldMapping(),
+		WriteMode:       writer.WriteMode(d.config.WriteMode),
+		SoftDelete:      d.config.SoftDeleteOptions(),
+		AppendColumns:   d.config.AppendColumns(),
+		SCD2Columns:     d.config.SCD2Columns(),
+		Checkpoints:     d.config.Checkpoints(),
+		VersionColumn:   d.config.VersionColumn,
+		Bulk:            d.config.BulkOptions(),
+		ReloadMode:      writer.ReloadMode(d.config.ReloadMode),
 	})
 
 	if err != nil {
@@ -89,13 +121,31 @@ func (d *Destination) Open(ctx context.Context) error {
 
 // Write writes a record into a Destination.
 func (d *Destination) Write(ctx context.Context, records []opencdc.Record) (int, error) {
+	if d.config.BulkMode {
+		// records before the failed batch are written, the rest of them is written again.
+		var written int
+		err := d.retry.Do(ctx, func() error {
+			n, err := d.writer.WriteBatch(ctx, records[written:])
+			written += n
+
+			return err //nolint:wrapcheck // wrapped below
+		})
+		if err != nil {
+			return written, fmt.Errorf("write batch: %w", err)
+		}
+
+		return written, nil
+	}
+
 	for i, record := range records {
-		err := sdk.Util.Destination.Route(ctx, record,
-			d.writer.Insert,
-			d.writer.Update,
-			d.writer.Delete,
-			d.writer.Insert,
-		)
+		err := d.retry.Do(ctx, func() error {
+			return sdk.Util.Destination.Route(ctx, record, //nolint:wrapcheck // wrapped below
+				d.writer.Insert,
+				d.writer.Update,
+				d.writer.Delete,
+				d.writer.Insert,
+			)
+		})
 		if err != nil {
 			return i, fmt.Errorf("route %s: %w", record.Operation.String(), err)
 		}
@@ -106,9 +156,19 @@ func (d *Destination) Write(ctx context.Context, records []opencdc.Record) (int,
 
 // Teardown gracefully closes connections.
 func (d *Destination) Teardown(ctx context.Context) error {
+	var err error
+
 	if d.writer != nil {
-		return d.writer.Close(ctx)
+		err = d.writer.Close(ctx)
 	}
 
-	return nil
+	if d.db != nil {
+		if er := d.db.Close(); er != nil && err == nil {
+			err = fmt.Errorf("close db: %w", er)
+		}
+
+		d.db = nil
+	}
+
+	return err
 }
@@ -20,11 +20,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/conduitio-labs/conduit-connector-db2/common"
+	"github.com/conduitio-labs/conduit-connector-db2/destination/config"
 	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
 )
 
 const (
@@ -47,8 +51,38 @@ const (
 	queryDropTable = `
 		DROP TABLE %s;
 `
+
+	queryCreateUsersTable = `
+	CREATE TABLE %s (
+		ID INT NOT NULL PRIMARY KEY,
+		NAME VARCHAR(40),
+		AGE INT,
+		VERSION INT
+	)`
+	queryInsertUsers = `
+	INSERT INTO %s (ID, NAME, AGE, VERSION) VALUES
+		(2, 'old', 30, 5),
+		(3, 'deleted', 40, 1)`
+	querySelectUsers         = "SELECT ID, NAME, AGE FROM %s ORDER BY ID"
+	queryCountUserTablespace = "SELECT COUNT(*) FROM SYSCAT.TABLESPACES WHERE DATATYPE = 'U'"
+	// the global temporary staging table requires a user temporary tablespace, which the test database doesn't have.
+	queryCreateUserTablespace = "CREATE USER TEMPORARY TABLESPACE CONDUIT_USERTEMP MANAGED BY AUTOMATIC STORAGE"
+	queryCountTable           = "SELECT COUNT(*) FROM SYSCAT.TABLES WHERE TABSCHEMA = CURRENT SCHEMA AND TABNAME = ?"
+	queryColumnType           = `
+	SELECT TYPENAME, LENGTH FROM SYSCAT.COLUMNS
+	WHERE TABSCHEMA = CURRENT SCHEMA AND TABNAME = ? AND COLNAME = ?`
+	querySelectCheckpoint = "SELECT POSITION, RELOADING FROM %s WHERE PIPELINE = ? AND TABLE_NAME = ?"
+
+	integrationPipeline = "integration"
 )
 
+// integrationUser is a row of the users table.
+type integrationUser struct {
+	ID   int
+	Name string
+	Age  int
+}
+
 func TestIntegrationDestination_Write_Insert_Success(t *testing.T) {
 	var preparedID = 1
 
@@ -415,3 +449,415 @@ func clearData(ctx context.Context, connection string) error {
 
 	return nil
 }
+
+func TestIntegrationDestination_WriteBatch(t *testing.T) {
+	changes := []opencdc.Record{
+		userRecord(opencdc.OperationCreate, "1", 1, opencdc.StructuredData{"id": 1, "name": "new", "age": 20}),
+		// the age of the row is kept, since it is not in the payload.
+		userRecord(opencdc.OperationUpdate, "2", 2, opencdc.StructuredData{"id": 2, "name": "updated"}),
+		userRecord(opencdc.OperationDelete, "3", 3, nil),
+	}
+
+	tests := []struct {
+		name    string
+		cfg     map[string]string
+		records []opencdc.Record
+		want    []integrationUser
+	}{
+		{
+			name:    "temporary staging",
+			cfg:     map[string]string{config.ConfigBulkStaging: "temporary"},
+			records: changes,
+			want:    []integrationUser{{1, "new", 20}, {2, "updated", 30}},
+		},
+		{
+			name:    "table staging",
+			cfg:     map[string]string{config.ConfigBulkStaging: "table"},
+			records: changes,
+			want:    []integrationUser{{1, "new", 20}, {2, "updated", 30}},
+		},
+		{
+			name:    "load",
+			cfg:     map[string]string{config.ConfigBulkStaging: "table", config.ConfigBulkLoadMethod: "load"},
+			records: changes,
+			want:    []integrationUser{{1, "new", 20}, {2, "updated", 30}},
+		},
+		{
+			name: "version conflicts",
+			cfg:  map[string]string{config.ConfigVersionColumn: "VERSION"},
+			records: []opencdc.Record{
+				userRecord(opencdc.OperationUpdate, "1", 2,
+					opencdc.StructuredData{"id": 2, "name": "stale", "age": 31, "version": 4}),
+				userRecord(opencdc.OperationUpdate, "2", 3,
+					opencdc.StructuredData{"id": 3, "name": "newer", "age": 41, "version": 2}),
+				userRecord(opencdc.OperationUpdate, "3", 4,
+					opencdc.StructuredData{"id": 4, "name": "missing", "age": 50, "version": 1}),
+			},
+			want: []integrationUser{{2, "old", 30}, {3, "newer", 41}, {4, "missing", 50}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			cfg, db := prepareUsersTable(ctx, t)
+
+			if err := prepareUserTablespace(ctx, db); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg[config.ConfigBulkMode] = "true"
+			for key, value := range tt.cfg {
+				cfg[key] = value
+			}
+
+			dest := openDestination(ctx, t, cfg)
+
+			count, err := dest.Write(ctx, tt.records)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if count != len(tt.records) {
+				t.Fatalf("count = %d, want %d", count, len(tt.records))
+			}
+
+			if got := readUsers(ctx, t, db, cfg[common.ConfigurationTable]); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("users = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntegrationDestination_Write_Checkpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		bulkMode string
+	}{
+		{name: "standard", bulkMode: "false"},
+		{name: "bulk", bulkMode: "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			cfg, db := prepareUsersTable(ctx, t)
+			tableName := cfg[common.ConfigurationTable]
+			cfg[config.ConfigBulkMode] = tt.bulkMode
+			cfg[config.ConfigBulkStaging] = "table"
+
+			first := openDestination(ctx, t, cfg)
+
+			_, err := first.Write(ctx, []opencdc.Record{
+				userRecord(opencdc.OperationCreate, "1", 1, opencdc.StructuredData{"id": 1, "name": "new", "age": 20}),
+				userRecord(opencdc.OperationUpdate, "2", 2, opencdc.StructuredData{"id": 2, "name": "updated", "age": 31}),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = first.Teardown(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			// the record at the checkpoint is redelivered after the restart and must not be written again.
+			second := openDestination(ctx, t, cfg)
+
+			count, err := second.Write(ctx, []opencdc.Record{
+				userRecord(opencdc.OperationUpdate, "2", 2, opencdc.StructuredData{"id": 2, "name": "again", "age": 32}),
+				userRecord(opencdc.OperationCreate, "3", 4, opencdc.StructuredData{"id": 4, "name": "after", "age": 50}),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if count != 2 {
+				t.Fatalf("count = %d, want 2", count)
+			}
+
+			want := []integrationUser{{1, "new", 20}, {2, "updated", 31}, {3, "deleted", 40}, {4, "after", 50}}
+			if got := readUsers(ctx, t, db, tableName); !reflect.DeepEqual(got, want) {
+				t.Fatalf("users = %v, want %v", got, want)
+			}
+
+			position, reloading := readCheckpoint(ctx, t, db, cfg[config.ConfigCheckpointTable], tableName)
+			if position != "3" || reloading != 0 {
+				t.Fatalf("checkpoint = %q, reloading %d, want \"3\", reloading 0", position, reloading)
+			}
+		})
+	}
+}
+
+func TestIntegrationDestination_Write_Reload(t *testing.T) {
+	tests := []struct {
+		name       string
+		reloadMode string
+		bulkMode   string
+	}{
+		{name: "truncate", reloadMode: "truncate", bulkMode: "false"},
+		{name: "swap", reloadMode: "swap", bulkMode: "false"},
+		{name: "swap bulk", reloadMode: "swap", bulkMode: "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			cfg, db := prepareUsersTable(ctx, t)
+			tableName := cfg[common.ConfigurationTable]
+			cfg[config.ConfigReloadMode] = tt.reloadMode
+			cfg[config.ConfigBulkMode] = tt.bulkMode
+			cfg[config.ConfigBulkStaging] = "table"
+
+			dest := openDestination(ctx, t, cfg)
+
+			last := userRecord(opencdc.OperationSnapshot, "2", 2, opencdc.StructuredData{"id": 2, "name": "reloaded", "age": 32})
+			last.Metadata = opencdc.Metadata{"db2.snapshot.completed": "true"}
+
+			_, err := dest.Write(ctx, []opencdc.Record{
+				userRecord(opencdc.OperationSnapshot, "1", 1, opencdc.StructuredData{"id": 1, "name": "new", "age": 20}),
+				last,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the row 3 is not in the snapshot, so the reload removes it.
+			want := []integrationUser{{1, "new", 20}, {2, "reloaded", 32}}
+			if got := readUsers(ctx, t, db, tableName); !reflect.DeepEqual(got, want) {
+				t.Fatalf("users = %v, want %v", got, want)
+			}
+
+			if countTables(ctx, t, db, "CONDUIT_RELOAD_"+tableName) != 0 {
+				t.Fatal("shadow table is not dropped")
+			}
+
+			position, reloading := readCheckpoint(ctx, t, db, cfg[config.ConfigCheckpointTable], tableName)
+			if position != "2" || reloading != 0 {
+				t.Fatalf("checkpoint = %q, reloading %d, want \"2\", reloading 0", position, reloading)
+			}
+		})
+	}
+}
+
+func TestIntegrationDestination_Write_SchemaEvolution(t *testing.T) {
+	ctx := context.Background()
+
+	cfg, db := prepareUsersTable(ctx, t)
+	tableName := cfg[common.ConfigurationTable]
+	cfg[config.ConfigSchemaEvolution] = "evolve"
+	cfg[config.ConfigWidenVarchar] = "true"
+
+	dest := openDestination(ctx, t, cfg)
+
+	longName := strings.Repeat("n", 100)
+
+	_, err := dest.Write(ctx, []opencdc.Record{
+		userRecord(opencdc.OperationCreate, "1", 1,
+			opencdc.StructuredData{"id": 1, "name": longName, "age": 20, "email": "user@example.com"}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var email string
+
+	err = db.QueryRowContext(ctx, fmt.Sprintf("SELECT EMAIL FROM %s WHERE ID = 1", tableName)).Scan(&email)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if email != "user@example.com" {
+		t.Fatalf("email = %q, want %q", email, "user@example.com")
+	}
+
+	var (
+		typeName string
+		length   int
+	)
+
+	err = db.QueryRowContext(ctx, queryColumnType, tableName, "NAME").Scan(&typeName, &length)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if typeName != "VARCHAR" || length != 128 {
+		t.Fatalf("NAME column = %s(%d), want VARCHAR(128)", typeName, length)
+	}
+
+	want := []integrationUser{{1, longName, 20}, {2, "old", 30}, {3, "deleted", 40}}
+	if got := readUsers(ctx, t, db, tableName); !reflect.DeepEqual(got, want) {
+		t.Fatalf("users = %v, want %v", got, want)
+	}
+}
+
+// prepareUsersTable creates a users table with the rows 2 and 3, and returns the configuration of a destination
+// writing to it with checkpoints, and a connection to the database. The tables are dropped when the test ends.
+func prepareUsersTable(ctx context.Context, t *testing.T) (map[string]string, *sql.DB) {
+	t.Helper()
+
+	cfg, err := prepareConfig()
+	if err != nil {
+		t.Skip(err)
+	}
+
+	db, err := sql.Open("go_ibm_db", cfg[common.ConfigurationConnection])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	tableName := randomIdentifier(t)
+	checkpointTable := tableName + "_CHECKPOINTS"
+
+	t.Cleanup(func() {
+		// tables which were not created by the test fail to drop.
+		tables := []string{
+			tableName, checkpointTable, "CONDUIT_STAGE_" + tableName, "CONDUIT_RELOAD_" + tableName,
+			"CONDUIT_STAGE_CONDUIT_RELOAD_" + tableName,
+		}
+		for _, table := range tables {
+			db.ExecContext(context.Background(), fmt.Sprintf(queryDropTable, table)) //nolint:errcheck,nolintlint
+		}
+	})
+
+	_, err = db.ExecContext(ctx, fmt.Sprintf(queryCreateUsersTable, tableName))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.ExecContext(ctx, fmt.Sprintf(queryInsertUsers, tableName))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg[common.ConfigurationTable] = tableName
+	cfg[config.ConfigCheckpointTable] = checkpointTable
+	cfg[config.ConfigCheckpointPipeline] = integrationPipeline
+
+	return cfg, db
+}
+
+// prepareUserTablespace creates a user temporary tablespace if the database doesn't have one.
+func prepareUserTablespace(ctx context.Context, db *sql.DB) error {
+	var count int
+	if err := db.QueryRowContext(ctx, queryCountUserTablespace).Scan(&count); err != nil {
+		return fmt.Errorf("count user temporary tablespaces: %w", err)
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	if _, err := db.ExecContext(ctx, queryCreateUserTablespace); err != nil {
+		return fmt.Errorf("create user temporary tablespace: %w", err)
+	}
+
+	return nil
+}
+
+// openDestination configures and opens a destination, which is torn down when the test ends.
+func openDestination(ctx context.Context, t *testing.T, cfg map[string]string) sdk.Destination {
+	t.Helper()
+
+	dest := NewDestination()
+
+	if err := dest.Configure(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dest.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		dest.Teardown(context.Background()) //nolint:errcheck,nolintlint
+	})
+
+	return dest
+}
+
+// userRecord returns a record of the users table with the position and the key, deletes have no payload.
+func userRecord(operation opencdc.Operation, position string, id int, payload opencdc.StructuredData) opencdc.Record {
+	record := opencdc.Record{
+		Position:  opencdc.Position(position),
+		Operation: operation,
+		Key:       opencdc.StructuredData{"id": id},
+	}
+
+	if payload != nil {
+		record.Payload.After = payload
+	}
+
+	return record
+}
+
+// readUsers returns the rows of the users table ordered by ID.
+func readUsers(ctx context.Context, t *testing.T, db *sql.DB, tableName string) []integrationUser {
+	t.Helper()
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(querySelectUsers, tableName))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer rows.Close()
+
+	var users []integrationUser
+	for rows.Next() {
+		var user integrationUser
+		if err = rows.Scan(&user.ID, &user.Name, &user.Age); err != nil {
+			t.Fatal(err)
+		}
+
+		users = append(users, user)
+	}
+	if err = rows.Err(); err != nil {
+		t.Fatalf("error iterating rows: %v", err)
+	}
+
+	return users
+}
+
+// readCheckpoint returns the position and the reloading marker of the table's checkpoint.
+func readCheckpoint(ctx context.Context, t *testing.T, db *sql.DB, checkpointTable, tableName string) (string, int) {
+	t.Helper()
+
+	var (
+		position  []byte
+		reloading int
+	)
+
+	err := db.QueryRowContext(ctx, fmt.Sprintf(querySelectCheckpoint, checkpointTable), integrationPipeline, tableName).
+		Scan(&position, &reloading)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(position), reloading
+}
+
+// countTables returns the number of tables with the name in the current schema.
+func countTables(ctx context.Context, t *testing.T, db *sql.DB, tableName string) int {
+	t.Helper()
+
+	var count int
+	if err := db.QueryRowContext(ctx, queryCountTable, tableName).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+
+	return count
+}
+
+func randomIdentifier(t *testing.T) string {
+	t.Helper()
+
+	return strings.ToUpper(fmt.Sprintf("%v_%d",
+		strings.ReplaceAll(strings.ToLower(t.Name()), "/", "_"),
+		time.Now().UnixMicro()%1000))
+}
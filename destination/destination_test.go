@@ -17,9 +17,11 @@ package destination
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/conduitio-labs/conduit-connector-db2/common"
+	"github.com/conduitio-labs/conduit-connector-db2/destination/config"
 	"github.com/conduitio-labs/conduit-connector-db2/destination/mock"
 	"github.com/conduitio-labs/conduit-connector-db2/destination/writer"
 	"github.com/conduitio/conduit-commons/opencdc"
@@ -58,6 +60,181 @@ func TestDestination_Configure(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "fail, invalid binary encoding",
+			args: args{
+				cfg: map[string]string{
+					common.ConfigurationConnection: "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd",
+					common.ConfigurationTable:      "CLIENTS",
+					config.ConfigBinaryEncoding:    "base32",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "success, table template",
+			args: args{
+				cfg: map[string]string{
+					common.ConfigurationConnection: "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd",
+					common.ConfigurationTable:      `STAGE_{{ index .Metadata "opencdc.collection" }}`,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "fail, invalid table template",
+			args: args{
+				cfg: map[string]string{
+					common.ConfigurationConnection: "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd",
+					common.ConfigurationTable:      "STAGE_{{ .Metadata",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "success, append only",
+			args: args{
+				cfg: map[string]string{
+					common.ConfigurationConnection: "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd",
+					common.ConfigurationTable:      "CLIENTS_LOG",
+					config.ConfigWriteMode:         "appendOnly",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "fail, soft delete column too long",
+			args: args{
+				cfg: map[string]string{
+					common.ConfigurationConnection: "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd",
+					common.ConfigurationTable:      "CLIENTS",
+					config.ConfigWriteMode:         "softDelete",
+					config.ConfigSoftDeleteColumn:  strings.Repeat("D", 129),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail, append only with the same columns",
+			args: args{
+				cfg: map[string]string{
+					common.ConfigurationConnection: "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd",
+					common.ConfigurationTable:      "CLIENTS_LOG",
+					config.ConfigWriteMode:         "appendOnly",
+					config.ConfigPositionColumn:    "CONDUIT_OPERATION",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail, scd2 with the same columns",
+			args: args{
+				cfg: map[string]string{
+					common.ConfigurationConnection: "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd",
+					common.ConfigurationTable:      "CLIENTS_HISTORY",
+					config.ConfigWriteMode:         "scd2",
+					config.ConfigValidToColumn:     "valid_from",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "success, bulk mode",
+			args: args{
+				cfg: map[string]string{
+					common.ConfigurationConnection: "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd",
+					common.ConfigurationTable:      "CLIENTS",
+					config.ConfigBulkMode:          "true",
+					config.ConfigBulkStaging:       "table",
+					config.ConfigBulkLoadMethod:    "load",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "fail, bulk load into a temporary table",
+			args: args{
+				cfg: map[string]string{
+					common.ConfigurationConnection: "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd",
+					common.ConfigurationTable:      "CLIENTS",
+					config.ConfigBulkMode:          "true",
+					config.ConfigBulkLoadMethod:    "load",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail, bulk load on z/OS",
+			args: args{
+				cfg: map[string]string{
+					common.ConfigurationConnection: "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd",
+					common.ConfigurationTable:      "CLIENTS",
+					common.ConfigurationPlatform:   "zos",
+					config.ConfigBulkMode:          "true",
+					config.ConfigBulkStaging:       "table",
+					config.ConfigBulkLoadMethod:    "load",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail, unknown platform",
+			args: args{
+				cfg: map[string]string{
+					common.ConfigurationConnection: "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd",
+					common.ConfigurationTable:      "CLIENTS",
+					common.ConfigurationPlatform:   "iseries",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail, bulk mode with scd2",
+			args: args{
+				cfg: map[string]string{
+					common.ConfigurationConnection: "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd",
+					common.ConfigurationTable:      "CLIENTS",
+					config.ConfigBulkMode:          "true",
+					config.ConfigWriteMode:         "scd2",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "success, swap reload",
+			args: args{
+				cfg: map[string]string{
+					common.ConfigurationConnection: "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd",
+					common.ConfigurationTable:      "CLIENTS",
+					config.ConfigReloadMode:        "swap",
+					config.ConfigCheckpointTable:   "CONDUIT_CHECKPOINTS",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "fail, truncate reload without checkpoints",
+			args: args{
+				cfg: map[string]string{
+					common.ConfigurationConnection: "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd",
+					common.ConfigurationTable:      "CLIENTS",
+					config.ConfigReloadMode:        "truncate",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail, truncate reload of an append only table",
+			args: args{
+				cfg: map[string]string{
+					common.ConfigurationConnection: "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd",
+					common.ConfigurationTable:      "CLIENTS_LOG",
+					config.ConfigWriteMode:         "appendOnly",
+					config.ConfigReloadMode:        "truncate",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "fail, missing table",
 			args: args{
@@ -119,6 +296,40 @@ func TestDestination_Write(t *testing.T) {
 		is.Equal(c, 1)
 	})
 
+	t.Run("success, retry after a deadlock", func(t *testing.T) {
+		t.Parallel()
+
+		is := is.New(t)
+
+		ctrl := gomock.NewController(t)
+		ctx := context.Background()
+
+		record := opencdc.Record{
+			Operation: opencdc.OperationCreate,
+			Key:       opencdc.StructuredData{"ID": 1},
+			Payload:   opencdc.Change{After: opencdc.StructuredData{"ID": 1}},
+		}
+
+		deadlock := errors.New("SQL0911N  The current transaction has been rolled back because of a deadlock " +
+			"or timeout.  Reason code \"2\".  SQLSTATE=40001")
+
+		w := mock.NewMockWriter(ctrl)
+		gomock.InOrder(
+			w.EXPECT().Insert(ctx, record).Return(deadlock),
+			w.EXPECT().Insert(ctx, record).Return(nil),
+		)
+
+		d := Destination{
+			writer: w,
+			retry:  common.Retry{MaxRetries: 1},
+		}
+
+		c, err := d.Write(ctx, []opencdc.Record{record})
+		is.NoErr(err)
+
+		is.Equal(c, 1)
+	})
+
 	t.Run("fail, empty payload", func(t *testing.T) {
 		t.Parallel()
 
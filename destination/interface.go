@@ -25,5 +25,6 @@ type Writer interface {
 	Delete(ctx context.Context, record opencdc.Record) error
 	Insert(ctx context.Context, record opencdc.Record) error
 	Update(ctx context.Context, record opencdc.Record) error
+	WriteBatch(ctx context.Context, records []opencdc.Record) (int, error)
 	Close(ctx context.Context) error
 }
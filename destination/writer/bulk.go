@@ -0,0 +1,733 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-db2/coltypes"
+	"github.com/conduitio-labs/conduit-connector-db2/common"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// Staging is a kind of the table batches are loaded into before they are applied to the target table.
+type Staging string
+
+const (
+	// StagingTemporary loads batches into a declared global temporary table.
+	StagingTemporary Staging = "temporary"
+	// StagingTable loads batches into a regular staging table, which is created if it doesn't exist.
+	StagingTable Staging = "table"
+)
+
+// LoadMethod is a way of loading batches into the staging table.
+type LoadMethod string
+
+const (
+	// LoadMethodInsert loads batches with multi-row INSERT statements.
+	LoadMethodInsert LoadMethod = "insert"
+	// LoadMethodLoad loads batches with the LOAD utility called through SYSPROC.ADMIN_CMD.
+	LoadMethodLoad LoadMethod = "load"
+)
+
+const (
+	// maxIdentifierLength is the maximum length of DB2 table and column names.
+	maxIdentifierLength = 128
+	// stagingPrefix is a prefix of staging table names.
+	stagingPrefix = "CONDUIT_STAGE_"
+	// stagingTemporarySchema is a schema of declared global temporary tables.
+	stagingTemporarySchema = "SESSION"
+	// operationColumn is a column of the staging table with the operation of the row.
+	operationColumn = "CONDUIT_OPERATION"
+	// operationUpsert marks rows which are merged into the target table.
+	operationUpsert = "U"
+	// operationDelete marks rows which are deleted from the target table.
+	operationDelete = "D"
+	// groupColumn is a column of the staging table with the group of upsert rows with the same columns.
+	groupColumn = "CONDUIT_GROUP"
+
+	// the outer join makes all columns of the staging table nullable, so delete rows contain only keys.
+	stagingColumns = `SELECT T.*, CAST(NULL AS CHAR(1)) AS "` + operationColumn + `", ` +
+		`CAST(NULL AS INTEGER) AS "` + groupColumn + `" FROM SYSIBM.SYSDUMMY1 LEFT JOIN %s AS T ON 1 = 0`
+
+	queryDeclareStaging = `DECLARE GLOBAL TEMPORARY TABLE %s AS (` + stagingColumns + `) DEFINITION ONLY ` +
+		`ON COMMIT DELETE ROWS NOT LOGGED WITH REPLACE`
+	queryCreateStaging = `CREATE TABLE %s AS (` + stagingColumns + `) DEFINITION ONLY`
+	queryClearStaging  = `DELETE FROM %s`
+	queryLoadStaging   = `CALL SYSPROC.ADMIN_CMD(?)`
+	commandLoad        = `LOAD FROM (SELECT * FROM (VALUES %s) AS V) OF CURSOR MESSAGES ON SERVER ` +
+		`%s INTO %s (%s) NONRECOVERABLE`
+)
+
+// BulkOptions are options of writing batches of records through a staging table.
+type BulkOptions struct {
+	// Enabled writes batches through a staging table instead of row by row.
+	Enabled bool
+	// Staging is a kind of the staging table.
+	Staging Staging
+	// LoadMethod is a way of loading batches into the staging table.
+	LoadMethod LoadMethod
+	// Rows is a maximum number of rows loaded into the staging table by a single statement.
+	Rows int
+}
+
+// bulkRow is a row of the staging table.
+type bulkRow struct {
+	operation string
+	// group - number of the group of upsert rows with the same columns, starting from 1.
+	group int
+	// columns - uppercase column name with its converted value.
+	columns opencdc.StructuredData
+}
+
+// WriteBatch writes the records through a staging table. Consecutive records of the same table are loaded
+// into the staging table and applied to the target table with a MERGE statement per set of payload columns
+// and a single DELETE statement, in a transaction. It returns the number of written records.
+func (w *Writer) WriteBatch(ctx context.Context, records []opencdc.Record) (int, error) {
+	for start := 0; start < len(records); {
+		tableName, err := w.getTableName(records[start])
+		if err != nil {
+			return start, err
+		}
+
+		end := start + 1
+		for ; end < len(records); end++ {
+			nextTable, err := w.getTableName(records[end])
+			if err != nil || nextTable != tableName ||
+				!w.sameReloadGroup(records[start], records[end]) {
+				break
+			}
+		}
+
+		// the reload of the table starts or finishes at the first record of the group.
+		tableName, err = w.resolveTable(ctx, records[start])
+		if err != nil {
+			return start, err
+		}
+
+		if err = w.writeBulk(ctx, tableName, records[start:end]); err != nil {
+			return start, fmt.Errorf("write batch of %q: %w", tableName, err)
+		}
+
+		// the last record of a snapshot is the last record of its group.
+		if err = w.finishCompletedSnapshot(ctx, records[end-1]); err != nil {
+			return end, err
+		}
+
+		start = end
+	}
+
+	return len(records), nil
+}
+
+// sameReloadGroup returns true if the records are written to the same table during a reload,
+// i.e. both or neither of them are snapshot records.
+func (w *Writer) sameReloadGroup(a, b opencdc.Record) bool {
+	if !w.isReloading() {
+		return true
+	}
+
+	return (a.Operation == opencdc.OperationSnapshot) == (b.Operation == opencdc.OperationSnapshot)
+}
+
+// writeBulk applies the records of the table.
+func (w *Writer) writeBulk(ctx context.Context, tableName string, records []opencdc.Record) error {
+	var pipeline string
+
+	if w.checkpointOpts.Table != "" {
+		var err error
+
+		pipeline, err = w.checkpointPipeline(records[0])
+		if err != nil {
+			return err
+		}
+
+		records, err = w.unappliedRecords(ctx, pipeline, tableName, records)
+		if err != nil || len(records) == 0 {
+			return err
+		}
+	}
+
+	rows, keyColumns, err := w.bulkRows(ctx, tableName, records)
+	if err != nil {
+		return err
+	}
+
+	tableInfo, err := w.tables.get(ctx, tableName)
+	if err != nil {
+		return err
+	}
+
+	columns, groups := bulkColumns(rows), bulkGroups(rows)
+	for _, column := range append(slices.Clone(columns), tableName) {
+		if err = common.ValidateIdentifier(column); err != nil {
+			return err
+		}
+	}
+
+	staging, err := w.prepareStaging(ctx, tableName, tableInfo)
+	if err != nil {
+		return err
+	}
+
+	if w.bulk.LoadMethod == LoadMethodLoad {
+		// the LOAD utility commits, so the batch is loaded before the transaction.
+		if err = w.loadStaging(ctx, staging, columns, rows); err != nil {
+			return err
+		}
+	}
+
+	err = w.inTransaction(ctx, func() error {
+		if w.bulk.Staging == StagingTemporary {
+			query := fmt.Sprintf(queryDeclareStaging, staging, quoteTable(tableName))
+			if _, err := w.conn().ExecContext(ctx, query); err != nil {
+				return fmt.Errorf("exec declare staging table: %w", err)
+			}
+		}
+
+		if w.bulk.LoadMethod == LoadMethodInsert {
+			if err := w.insertStaging(ctx, staging, columns, rows); err != nil {
+				return err
+			}
+		}
+
+		staged := make([]int, len(groups))
+		for _, row := range rows {
+			if row.group > 0 {
+				staged[row.group-1]++
+			}
+		}
+
+		for i, groupColumns := range groups {
+			if err := w.execBulkMerge(ctx, tableName, staging, i+1, groupColumns, keyColumns, staged[i]); err != nil {
+				return err
+			}
+		}
+
+		statements := []string{buildBulkDeleteQuery(tableName, staging, keyColumns)}
+
+		if w.bulk.Staging == StagingTable {
+			statements = append(statements, fmt.Sprintf(queryClearStaging, staging))
+		}
+
+		for _, statement := range statements {
+			if _, err := w.conn().ExecContext(ctx, statement); err != nil {
+				return fmt.Errorf("exec %q: %w", statement, err)
+			}
+		}
+
+		if pipeline == "" {
+			return nil
+		}
+
+		return w.saveCheckpoint(ctx, pipeline, tableName, records[len(records)-1].Position)
+	})
+	if err != nil {
+		return err
+	}
+
+	if pipeline != "" {
+		w.afterCommit(func() {
+			w.checkpoints[pipeline][tableName] = records[len(records)-1].Position
+		})
+	}
+
+	return nil
+}
+
+// unappliedRecords returns the records after the checkpoint of the pipeline and table.
+func (w *Writer) unappliedRecords(
+	ctx context.Context,
+	pipeline, tableName string,
+	records []opencdc.Record,
+) ([]opencdc.Record, error) {
+	unapplied := make([]opencdc.Record, 0, len(records))
+	for _, record := range records {
+		applied, err := w.isApplied(ctx, pipeline, tableName, record)
+		if err != nil {
+			return nil, err
+		}
+
+		if !applied {
+			unapplied = append(unapplied, record)
+		}
+	}
+
+	return unapplied, nil
+}
+
+// bulkRows returns the rows of the records, converted to the column types, and the key columns.
+// Only the last record of every key is kept, so the rows can be applied in any order.
+func (w *Writer) bulkRows(
+	ctx context.Context,
+	tableName string,
+	records []opencdc.Record,
+) ([]bulkRow, []string, error) {
+	var (
+		rows       = make([]bulkRow, 0, len(records))
+		keyColumns []string
+		rowIndexes = make(map[string]int, len(records))
+	)
+
+	for _, record := range records {
+		row, keys, err := w.bulkRow(ctx, tableName, record)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		columns := make([]string, 0, len(keys))
+		values := make([]string, 0, len(keys))
+
+		for column := range keys {
+			columns = append(columns, column)
+		}
+
+		sort.Strings(columns)
+
+		for _, column := range columns {
+			value := row.columns[column]
+			if b, ok := value.([]byte); ok {
+				value = hex.EncodeToString(b)
+			}
+
+			values = append(values, fmt.Sprintf("%T:%v", value, value))
+		}
+
+		switch {
+		case keyColumns == nil:
+			keyColumns = columns
+		case !slices.Equal(keyColumns, columns):
+			return nil, nil, fmt.Errorf("keys %v and %v: %w", keyColumns, columns, ErrInconsistentKeys)
+		}
+
+		signature := strings.Join(values, "\x00")
+		if i, ok := rowIndexes[signature]; ok {
+			rows[i] = row
+
+			continue
+		}
+
+		rowIndexes[signature] = len(rows)
+		rows = append(rows, row)
+	}
+
+	return rows, keyColumns, nil
+}
+
+// bulkRow returns the row of the record and its keys.
+func (w *Writer) bulkRow(
+	ctx context.Context,
+	tableName string,
+	record opencdc.Record,
+) (bulkRow, opencdc.StructuredData, error) {
+	if record.Operation == opencdc.OperationDelete {
+		_, keys, err := w.deleteKeys(ctx, tableName, record)
+		if err != nil {
+			return bulkRow{}, nil, err
+		}
+
+		return bulkRow{operation: operationDelete, columns: keys}, keys, nil
+	}
+
+	payload, err := w.structurizeData(record.Payload.After)
+	if err != nil {
+		return bulkRow{}, nil, fmt.Errorf("structurize payload: %w", err)
+	}
+
+	payload, err = w.columnMapping.mapPayload(payload)
+	if err != nil {
+		return bulkRow{}, nil, fmt.Errorf("map payload: %w", err)
+	}
+
+	if len(payload) == 0 {
+		return bulkRow{}, nil, ErrEmptyPayload
+	}
+
+	// scalar keys are resolved with the primary key of the table.
+	rawKeys, _ := w.structurizeData(record.Key)
+
+	rawKeys, err = w.columnMapping.mapKey(rawKeys)
+	if err != nil {
+		return bulkRow{}, nil, fmt.Errorf("map key: %w", err)
+	}
+
+	if err = w.prepareTable(ctx, tableName, record, rawKeys, payload); err != nil {
+		return bulkRow{}, nil, fmt.Errorf("prepare table: %w", err)
+	}
+
+	payload, err = w.convertRow(ctx, tableName, record, payload)
+	if err != nil {
+		return bulkRow{}, nil, err
+	}
+
+	tableInfo, err := w.tables.get(ctx, tableName)
+	if err != nil {
+		return bulkRow{}, nil, err
+	}
+
+	keys, err := w.resolveKeys(ctx, record, tableInfo, payload)
+	if err != nil {
+		return bulkRow{}, nil, err
+	}
+
+	columns := make(opencdc.StructuredData, len(payload)+len(keys))
+	for column, value := range payload {
+		columns[column] = value
+	}
+
+	for column, value := range keys {
+		columns[column] = value
+	}
+
+	return bulkRow{operation: operationUpsert, columns: columns}, keys, nil
+}
+
+// bulkColumns returns the sorted columns of the rows.
+func bulkColumns(rows []bulkRow) []string {
+	columns := make([]string, 0)
+	for _, row := range rows {
+		for column := range row.columns {
+			if !slices.Contains(columns, column) {
+				columns = append(columns, column)
+			}
+		}
+	}
+
+	sort.Strings(columns)
+
+	return columns
+}
+
+// bulkGroups numbers the upsert rows by their sets of columns and returns the columns of every group,
+// so the columns which are missing in the payload of a record are not updated by the MERGE of its group.
+func bulkGroups(rows []bulkRow) [][]string {
+	var (
+		groups  [][]string
+		indexes = make(map[string]int)
+	)
+
+	for i := range rows {
+		if rows[i].operation != operationUpsert {
+			continue
+		}
+
+		columns := slices.Sorted(maps.Keys(rows[i].columns))
+
+		signature := strings.Join(columns, "\x00")
+		group, ok := indexes[signature]
+		if !ok {
+			groups = append(groups, columns)
+			group = len(groups)
+			indexes[signature] = group
+		}
+
+		rows[i].group = group
+	}
+
+	return groups
+}
+
+// prepareStaging returns the delimited name of the staging table of the target table. A regular staging table
+// is created if it doesn't exist, and recreated if its columns don't match the target table.
+func (w *Writer) prepareStaging(ctx context.Context, tableName string, tableInfo coltypes.TableInfo) (string, error) {
+	if len(stagingPrefix)+len(tableName) > maxIdentifierLength {
+		return "", fmt.Errorf("staging table of %q: %w", tableName, ErrInvalidIdentifier)
+	}
+
+	if w.bulk.Staging == StagingTemporary {
+		return common.QuoteIdentifier(stagingTemporarySchema) + "." + quoteTable(stagingPrefix+tableName), nil
+	}
+
+	staging := stagingPrefix + tableName
+
+	stagingInfo, err := w.tables.get(ctx, staging)
+	if err != nil {
+		return "", err
+	}
+
+	if stagingMatches(stagingInfo, tableInfo) {
+		return quoteTable(staging), nil
+	}
+
+	if len(stagingInfo.ColumnTypes) > 0 {
+		if _, err = w.db.ExecContext(ctx, fmt.Sprintf(queryDropTable, quoteTable(staging))); err != nil {
+			return "", fmt.Errorf("exec drop staging table: %w", err)
+		}
+	}
+
+	if _, err = w.db.ExecContext(ctx,
+		fmt.Sprintf(queryCreateStaging, quoteTable(staging), quoteTable(tableName))); err != nil {
+		return "", fmt.Errorf("exec create staging table: %w", err)
+	}
+
+	w.tables.invalidate(staging)
+
+	return quoteTable(staging), nil
+}
+
+// stagingMatches returns true if the staging table has the columns of the target table with the same types,
+// lengths and scales, and the operation and group columns.
+func stagingMatches(stagingInfo, tableInfo coltypes.TableInfo) bool {
+	if len(stagingInfo.ColumnTypes) != len(tableInfo.ColumnTypes)+2 ||
+		stagingInfo.ColumnTypes[operationColumn] == "" || stagingInfo.ColumnTypes[groupColumn] == "" {
+		return false
+	}
+
+	for column, columnType := range tableInfo.ColumnTypes {
+		if stagingInfo.ColumnTypes[column] != columnType ||
+			stagingInfo.ColumnLengths[column] != tableInfo.ColumnLengths[column] ||
+			stagingInfo.ColumnScales[column] != tableInfo.ColumnScales[column] ||
+			stagingInfo.ForBitData[column] != tableInfo.ForBitData[column] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// insertStaging loads the rows into the staging table with multi-row INSERT statements.
+func (w *Writer) insertStaging(ctx context.Context, staging string, columns []string, rows []bulkRow) error {
+	for chunk := range slices.Chunk(rows, w.bulk.Rows) {
+		ib := sqlbuilder.NewInsertBuilder()
+
+		ib.InsertInto(staging)
+		ib.Cols(quoteColumns(append(slices.Clone(columns), operationColumn, groupColumn))...)
+
+		for _, row := range chunk {
+			values := make([]any, 0, len(columns)+2)
+			for _, column := range columns {
+				values = append(values, row.columns[column])
+			}
+
+			ib.Values(append(values, row.operation, row.group)...)
+		}
+
+		query, args := ib.Build()
+
+		if _, err := w.conn().ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("exec insert into staging table: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// loadStaging replaces the rows of the staging table with the rows using the LOAD utility.
+// Values are passed to the utility as SQL literals.
+func (w *Writer) loadStaging(ctx context.Context, staging string, columns []string, rows []bulkRow) error {
+	action := "REPLACE"
+
+	for chunk := range slices.Chunk(rows, w.bulk.Rows) {
+		command, err := buildLoadCommand(staging, action, columns, chunk)
+		if err != nil {
+			return err
+		}
+
+		if _, err = w.db.ExecContext(ctx, queryLoadStaging, command); err != nil {
+			return fmt.Errorf("exec load into staging table: %w", err)
+		}
+
+		action = "INSERT"
+	}
+
+	return nil
+}
+
+// buildLoadCommand generates the LOAD command of the rows.
+func buildLoadCommand(staging, action string, columns []string, rows []bulkRow) (string, error) {
+	values := make([]string, len(rows))
+	for i, row := range rows {
+		literals := make([]string, 0, len(columns)+2)
+		for _, column := range columns {
+			literal, err := sqlLiteral(row.columns[column])
+			if err != nil {
+				return "", fmt.Errorf("column %q: %w", column, err)
+			}
+
+			literals = append(literals, literal)
+		}
+
+		literals = append(literals, quoteString(row.operation), strconv.Itoa(row.group))
+		values[i] = "(" + strings.Join(literals, ", ") + ")"
+	}
+
+	return fmt.Sprintf(commandLoad, strings.Join(values, ", "), action, staging,
+		strings.Join(quoteColumns(append(slices.Clone(columns), operationColumn, groupColumn)), ", ")), nil
+}
+
+// execBulkMerge merges the staged upsert rows of the group into the target table. If the version column
+// is merged, staged rows which are neither inserted nor updated are counted and logged as stale.
+func (w *Writer) execBulkMerge(
+	ctx context.Context,
+	tableName, staging string,
+	group int,
+	columns, keyColumns []string,
+	staged int,
+) error {
+	query := w.buildBulkMergeQuery(tableName, staging, group, columns, keyColumns)
+
+	result, err := w.conn().ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("exec %q: %w", query, err)
+	}
+
+	if !w.isVersionedMerge(columns, keyColumns) {
+		return nil
+	}
+
+	merged, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get merged rows: %w", err)
+	}
+
+	rejected := staged - int(merged)
+	if rejected <= 0 {
+		return nil
+	}
+
+	w.staleRecords += rejected
+
+	sdk.Logger(ctx).Warn().
+		Str("table", tableName).
+		Int("rejected", rejected).
+		Int("staleRecords", w.staleRecords).
+		Msg("rejected stale updates of the batch, the stored versions are not older")
+
+	return nil
+}
+
+// isVersionedMerge returns true if matched rows are only updated by the MERGE of the columns
+// if the staged version is greater.
+func (w *Writer) isVersionedMerge(columns, keyColumns []string) bool {
+	if w.versionColumn == "" || len(columns) == len(keyColumns) {
+		return false
+	}
+
+	_, ok := columnOf(columns, w.versionColumn)
+
+	return ok
+}
+
+// buildBulkMergeQuery generates a MERGE statement of the upsert rows of the group into the target table,
+// which only writes the columns of the group. If the version column is set, matched rows are only updated
+// if the staged version is greater.
+func (w *Writer) buildBulkMergeQuery(tableName, staging string, group int, columns, keyColumns []string) string {
+	var (
+		on      = make([]string, len(keyColumns))
+		sets    = make([]string, 0, len(columns))
+		sources = make([]string, len(columns))
+	)
+
+	for i, column := range keyColumns {
+		on[i] = fmt.Sprintf("T.%s = S.%s", quoteColumn(column), quoteColumn(column))
+	}
+
+	for i, column := range columns {
+		sources[i] = "S." + quoteColumn(column)
+
+		if !slices.Contains(keyColumns, column) {
+			sets = append(sets, fmt.Sprintf("T.%s = S.%s", quoteColumn(column), quoteColumn(column)))
+		}
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "MERGE INTO %s AS T USING (SELECT %s FROM %s WHERE %s = '%s' AND %s = %d) AS S ON %s",
+		quoteTable(tableName), strings.Join(quoteColumns(columns), ", "), staging, quoteColumn(operationColumn),
+		operationUpsert, quoteColumn(groupColumn), group, strings.Join(on, " AND "))
+
+	if len(sets) > 0 {
+		sb.WriteString(" WHEN MATCHED")
+
+		if w.isVersionedMerge(columns, keyColumns) {
+			version, _ := columnOf(columns, w.versionColumn)
+			version = quoteColumn(version)
+			fmt.Fprintf(&sb, " AND (T.%s < S.%s OR T.%s IS NULL)", version, version, version)
+		}
+
+		fmt.Fprintf(&sb, " THEN UPDATE SET %s", strings.Join(sets, ", "))
+	}
+
+	fmt.Fprintf(&sb, " WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s)",
+		strings.Join(quoteColumns(columns), ", "), strings.Join(sources, ", "))
+
+	return sb.String()
+}
+
+// buildBulkDeleteQuery generates a DELETE statement of the target rows with the keys of the staged delete rows.
+func buildBulkDeleteQuery(tableName, staging string, keyColumns []string) string {
+	on := make([]string, len(keyColumns))
+	for i, column := range keyColumns {
+		on[i] = fmt.Sprintf("T.%s = S.%s", quoteColumn(column), quoteColumn(column))
+	}
+
+	return fmt.Sprintf("DELETE FROM %s AS T WHERE EXISTS (SELECT 1 FROM %s AS S WHERE S.%s = '%s' AND %s)",
+		quoteTable(tableName), staging, quoteColumn(operationColumn), operationDelete, strings.Join(on, " AND "))
+}
+
+// sqlLiteral returns the SQL literal of the converted value.
+func sqlLiteral(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "NULL", nil
+	case bool:
+		if v {
+			return "TRUE", nil
+		}
+
+		return "FALSE", nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case int, int8, int16, int32, uint8, uint16, uint32:
+		return fmt.Sprint(v), nil
+	case float32:
+		return sqlFloatLiteral(float64(v))
+	case float64:
+		return sqlFloatLiteral(v)
+	case string:
+		return quoteString(v), nil
+	case []byte:
+		return "BX'" + strings.ToUpper(hex.EncodeToString(v)) + "'", nil
+	case time.Time:
+		return "TIMESTAMP('" + v.Format("2006-01-02-15.04.05.000000000") + "')", nil
+	default:
+		return "", fmt.Errorf("%T: %w", value, ErrUnsupportedLiteral)
+	}
+}
+
+// sqlFloatLiteral returns the floating-point literal of the value.
+func sqlFloatLiteral(v float64) (string, error) {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return "", fmt.Errorf("%v: %w", v, ErrUnsupportedLiteral)
+	}
+
+	return strconv.FormatFloat(v, 'E', -1, 64), nil
+}
+
+// quoteString returns the string literal of the value.
+func quoteString(v string) string {
+	return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+}
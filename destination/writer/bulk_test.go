@@ -0,0 +1,235 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-db2/coltypes"
+	"github.com/matryer/is"
+)
+
+func TestWriter_buildBulkMergeQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		versionColumn string
+		columns       []string
+		want          string
+	}{
+		{
+			name:    "success",
+			columns: []string{"ID", "NAME"},
+			want: `MERGE INTO "USERS" AS T USING (SELECT "ID", "NAME" FROM "SESSION"."CONDUIT_STAGE_USERS" ` +
+				`WHERE "CONDUIT_OPERATION" = 'U' AND "CONDUIT_GROUP" = 1) AS S ON T."ID" = S."ID" ` +
+				`WHEN MATCHED THEN UPDATE SET T."NAME" = S."NAME" ` +
+				`WHEN NOT MATCHED THEN INSERT ("ID", "NAME") VALUES (S."ID", S."NAME")`,
+		},
+		{
+			name:          "success, version column",
+			versionColumn: "version",
+			columns:       []string{"ID", "VERSION"},
+			want: `MERGE INTO "USERS" AS T USING (SELECT "ID", "VERSION" FROM "SESSION"."CONDUIT_STAGE_USERS" ` +
+				`WHERE "CONDUIT_OPERATION" = 'U' AND "CONDUIT_GROUP" = 1) AS S ON T."ID" = S."ID" ` +
+				`WHEN MATCHED AND (T."VERSION" < S."VERSION" OR T."VERSION" IS NULL) ` +
+				`THEN UPDATE SET T."VERSION" = S."VERSION" ` +
+				`WHEN NOT MATCHED THEN INSERT ("ID", "VERSION") VALUES (S."ID", S."VERSION")`,
+		},
+		{
+			name:    "success, key columns only",
+			columns: []string{"ID"},
+			want: `MERGE INTO "USERS" AS T USING (SELECT "ID" FROM "SESSION"."CONDUIT_STAGE_USERS" ` +
+				`WHERE "CONDUIT_OPERATION" = 'U' AND "CONDUIT_GROUP" = 1) AS S ON T."ID" = S."ID" ` +
+				`WHEN NOT MATCHED THEN INSERT ("ID") VALUES (S."ID")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			w := &Writer{versionColumn: tt.versionColumn}
+
+			is.Equal(w.buildBulkMergeQuery("USERS", `"SESSION"."CONDUIT_STAGE_USERS"`, 1, tt.columns, []string{"ID"}),
+				tt.want)
+		})
+	}
+}
+
+func Test_bulkGroups(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	rows := []bulkRow{
+		{operation: operationUpsert, columns: map[string]any{"ID": int64(1), "NAME": "alex", "AGE": int64(30)}},
+		{operation: operationUpsert, columns: map[string]any{"ID": int64(2), "NAME": "kim"}},
+		{operation: operationDelete, columns: map[string]any{"ID": int64(3)}},
+		{operation: operationUpsert, columns: map[string]any{"ID": int64(4), "AGE": int64(40), "NAME": "lee"}},
+	}
+
+	is.Equal(bulkGroups(rows), [][]string{{"AGE", "ID", "NAME"}, {"ID", "NAME"}})
+	is.Equal([]int{rows[0].group, rows[1].group, rows[2].group, rows[3].group}, []int{1, 2, 0, 1})
+}
+
+func TestWriter_isVersionedMerge(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	w := &Writer{versionColumn: "version"}
+
+	is.True(w.isVersionedMerge([]string{"ID", "VERSION"}, []string{"ID"}))
+	is.True(!w.isVersionedMerge([]string{"ID", "NAME"}, []string{"ID"}))
+	is.True(!w.isVersionedMerge([]string{"ID"}, []string{"ID"}))
+	is.True(!(&Writer{}).isVersionedMerge([]string{"ID", "VERSION"}, []string{"ID"}))
+}
+
+func Test_stagingMatches(t *testing.T) {
+	t.Parallel()
+
+	tableInfo := coltypes.TableInfo{
+		ColumnTypes:   map[string]string{"ID": "INTEGER", "NAME": "VARCHAR"},
+		ColumnLengths: map[string]int{"ID": 4, "NAME": 100},
+		ColumnScales:  map[string]int{"ID": 0, "NAME": 0},
+	}
+
+	// staging returns the staging table info of the columns.
+	staging := func(columnTypes map[string]string, nameLength int) coltypes.TableInfo {
+		columnTypes[operationColumn] = "CHARACTER"
+		columnTypes[groupColumn] = "INTEGER"
+
+		return coltypes.TableInfo{
+			ColumnTypes:   columnTypes,
+			ColumnLengths: map[string]int{"ID": 4, "NAME": nameLength, "FULL_NAME": nameLength},
+			ColumnScales:  map[string]int{"ID": 0, "NAME": 0},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		staging coltypes.TableInfo
+		want    bool
+	}{
+		{
+			name:    "same columns",
+			staging: staging(map[string]string{"ID": "INTEGER", "NAME": "VARCHAR"}, 100),
+			want:    true,
+		},
+		{
+			name:    "renamed column",
+			staging: staging(map[string]string{"ID": "INTEGER", "FULL_NAME": "VARCHAR"}, 100),
+			want:    false,
+		},
+		{
+			name:    "retyped column",
+			staging: staging(map[string]string{"ID": "BIGINT", "NAME": "VARCHAR"}, 100),
+			want:    false,
+		},
+		{
+			name:    "widened column",
+			staging: staging(map[string]string{"ID": "INTEGER", "NAME": "VARCHAR"}, 50),
+			want:    false,
+		},
+		{
+			name:    "missing table",
+			staging: coltypes.TableInfo{},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			is.Equal(stagingMatches(tt.staging, tableInfo), tt.want)
+		})
+	}
+}
+
+func TestBuildBulkDeleteQuery(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	is.Equal(buildBulkDeleteQuery("USERS", `"CONDUIT_STAGE_USERS"`, []string{"ID", "REGION"}),
+		`DELETE FROM "USERS" AS T WHERE EXISTS (SELECT 1 FROM "CONDUIT_STAGE_USERS" AS S `+
+			`WHERE S."CONDUIT_OPERATION" = 'D' AND T."ID" = S."ID" AND T."REGION" = S."REGION")`)
+}
+
+func TestBuildLoadCommand(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	rows := []bulkRow{
+		{operation: operationUpsert, group: 1, columns: map[string]any{"ID": int64(1), "NAME": "O'Brien"}},
+		{operation: operationDelete, columns: map[string]any{"ID": int64(2)}},
+	}
+
+	command, err := buildLoadCommand(`"CONDUIT_STAGE_USERS"`, "REPLACE", bulkColumns(rows), rows)
+	is.NoErr(err)
+	is.Equal(command, `LOAD FROM (SELECT * FROM (VALUES (1, 'O''Brien', 'U', 1), (2, NULL, 'D', 0)) AS V) OF CURSOR `+
+		`MESSAGES ON SERVER REPLACE INTO "CONDUIT_STAGE_USERS" ("ID", "NAME", "CONDUIT_OPERATION", "CONDUIT_GROUP") `+
+		`NONRECOVERABLE`)
+
+	_, err = buildLoadCommand("CONDUIT_STAGE_USERS", "INSERT", []string{"ID"},
+		[]bulkRow{{operation: operationUpsert, columns: map[string]any{"ID": math.NaN()}}})
+	is.True(errors.Is(err, ErrUnsupportedLiteral))
+}
+
+func TestSQLLiteral(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   any
+		want    string
+		wantErr error
+	}{
+		{name: "nil", value: nil, want: "NULL"},
+		{name: "bool", value: true, want: "TRUE"},
+		{name: "int", value: int64(-42), want: "-42"},
+		{name: "float", value: 1.5, want: "1.5E+00"},
+		{name: "string", value: "it's", want: "'it''s'"},
+		{name: "bytes", value: []byte{0xca, 0xfe}, want: "BX'CAFE'"},
+		{
+			name:  "time",
+			value: time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+			want:  "TIMESTAMP('2024-01-02-03.04.05.000000006')",
+		},
+		{name: "infinity", value: math.Inf(1), wantErr: ErrUnsupportedLiteral},
+		{name: "unsupported", value: struct{}{}, wantErr: ErrUnsupportedLiteral},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			got, err := sqlLiteral(tt.value)
+			if tt.wantErr != nil {
+				is.True(errors.Is(err, tt.wantErr))
+
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
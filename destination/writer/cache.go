@@ -0,0 +1,66 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conduitio-labs/conduit-connector-db2/coltypes"
+)
+
+// tableInfoCache is a lazily filled cache of column types and primary keys of destination tables.
+type tableInfoCache struct {
+	querier coltypes.Querier
+	// tables - table name with information about the table.
+	tables map[string]coltypes.TableInfo
+}
+
+func newTableInfoCache(querier coltypes.Querier) *tableInfoCache {
+	return &tableInfoCache{
+		querier: querier,
+		tables:  make(map[string]coltypes.TableInfo),
+	}
+}
+
+// get returns information about the table, loading it on the first call.
+// Tables which don't exist are not cached, so they are looked up again after they are created.
+func (c *tableInfoCache) get(ctx context.Context, table string) (coltypes.TableInfo, error) {
+	if tableInfo, ok := c.tables[table]; ok {
+		return tableInfo, nil
+	}
+
+	tableInfo, err := coltypes.GetTableInfo(ctx, c.querier, table)
+	if err != nil {
+		return coltypes.TableInfo{}, fmt.Errorf("get column types of %q: %w", table, err)
+	}
+
+	if len(tableInfo.ColumnTypes) > 0 {
+		c.tables[table] = tableInfo
+	}
+
+	return tableInfo, nil
+}
+
+// invalidate removes the table from the cache, so it is reloaded on the next call.
+func (c *tableInfoCache) invalidate(table string) {
+	delete(c.tables, table)
+}
+
+// reset removes all tables from the cache, e.g. after a rollback of a transaction which created
+// or changed tables.
+func (c *tableInfoCache) reset() {
+	c.tables = make(map[string]coltypes.TableInfo)
+}
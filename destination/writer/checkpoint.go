@@ -0,0 +1,279 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/conduitio-labs/conduit-connector-db2/common"
+	"github.com/conduitio-labs/conduit-connector-db2/source/position"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+const (
+	queryCreateCheckpointTable = `
+	CREATE TABLE %s (
+		PIPELINE VARCHAR(255) NOT NULL,
+		TABLE_NAME VARCHAR(128) NOT NULL,
+		POSITION BLOB(1M),
+		RELOADING SMALLINT NOT NULL DEFAULT 0,
+		UPDATED_AT TIMESTAMP NOT NULL DEFAULT CURRENT TIMESTAMP,
+		PRIMARY KEY (PIPELINE, TABLE_NAME)
+	)`
+
+	queryGetCheckpoints = `SELECT TABLE_NAME, POSITION, RELOADING FROM %s WHERE PIPELINE = ?`
+
+	queryMergeCheckpoint = `
+	MERGE INTO %s AS T
+	USING (VALUES (CAST(? AS VARCHAR(255)), CAST(? AS VARCHAR(128)), CAST(? AS BLOB(1M))))
+		AS S (PIPELINE, TABLE_NAME, POSITION)
+	ON T.PIPELINE = S.PIPELINE AND T.TABLE_NAME = S.TABLE_NAME
+	WHEN MATCHED THEN
+		UPDATE SET T.POSITION = S.POSITION, T.UPDATED_AT = CURRENT TIMESTAMP
+	WHEN NOT MATCHED THEN
+		INSERT (PIPELINE, TABLE_NAME, POSITION) VALUES (S.PIPELINE, S.TABLE_NAME, S.POSITION)`
+
+	queryMergeReloading = `
+	MERGE INTO %s AS T
+	USING (VALUES (CAST(? AS VARCHAR(255)), CAST(? AS VARCHAR(128)), CAST(? AS SMALLINT)))
+		AS S (PIPELINE, TABLE_NAME, RELOADING)
+	ON T.PIPELINE = S.PIPELINE AND T.TABLE_NAME = S.TABLE_NAME
+	WHEN MATCHED THEN
+		UPDATE SET T.RELOADING = S.RELOADING, T.UPDATED_AT = CURRENT TIMESTAMP
+	WHEN NOT MATCHED THEN
+		INSERT (PIPELINE, TABLE_NAME, RELOADING) VALUES (S.PIPELINE, S.TABLE_NAME, S.RELOADING)`
+)
+
+// Checkpoints are options of storing positions of the applied records in the destination database.
+type Checkpoints struct {
+	// Table is a name of the checkpoint table, checkpoints are disabled if it is empty.
+	Table string
+	// Pipeline identifies the pipeline in the checkpoint table.
+	// If it is empty, the source connector ID from the record metadata is used.
+	Pipeline string
+}
+
+// ensureCheckpointTable creates the checkpoint table if it doesn't exist.
+func (w *Writer) ensureCheckpointTable(ctx context.Context) error {
+	if err := common.ValidateIdentifier(w.checkpointOpts.Table); err != nil {
+		return fmt.Errorf("checkpoint table: %w", err)
+	}
+
+	tableInfo, err := w.tables.get(ctx, w.checkpointOpts.Table)
+	if err != nil {
+		return err
+	}
+
+	if len(tableInfo.ColumnTypes) > 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf(queryCreateCheckpointTable, quoteTable(w.checkpointOpts.Table))
+	if _, err = w.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("exec create checkpoint table %q: %w", w.checkpointOpts.Table, err)
+	}
+
+	w.tables.invalidate(w.checkpointOpts.Table)
+
+	return nil
+}
+
+// write writes the record with the write function. If checkpoints are enabled, records at or before
+// the checkpoint of the pipeline and table are skipped, and the checkpoint is updated in the transaction
+// of the write.
+func (w *Writer) write(ctx context.Context, record opencdc.Record, tableName string, write func() error) error {
+	if w.checkpointOpts.Table == "" {
+		return w.withRefresh(ctx, tableName, write)
+	}
+
+	pipeline, err := w.checkpointPipeline(record)
+	if err != nil {
+		return err
+	}
+
+	applied, err := w.isApplied(ctx, pipeline, tableName, record)
+	if err != nil || applied {
+		return err
+	}
+
+	err = w.inTransaction(ctx, func() error {
+		if err := w.withRefresh(ctx, tableName, write); err != nil {
+			return err
+		}
+
+		return w.saveCheckpoint(ctx, pipeline, tableName, record.Position)
+	})
+	if err != nil {
+		return err
+	}
+
+	w.afterCommit(func() {
+		w.checkpoints[pipeline][tableName] = record.Position
+	})
+
+	return nil
+}
+
+// checkpointPipeline returns the pipeline of the record in the checkpoint table.
+func (w *Writer) checkpointPipeline(record opencdc.Record) (string, error) {
+	if w.checkpointOpts.Pipeline != "" {
+		return w.checkpointOpts.Pipeline, nil
+	}
+
+	connectorID, err := record.Metadata.GetConduitSourceConnectorID()
+	if err != nil {
+		return "", fmt.Errorf("get pipeline of the checkpoint: %w", err)
+	}
+
+	return connectorID, nil
+}
+
+// isApplied returns true if the position of the record is at or before the checkpoint of the pipeline and table.
+func (w *Writer) isApplied(ctx context.Context, pipeline, tableName string, record opencdc.Record) (bool, error) {
+	checkpoints, err := w.pipelineCheckpoints(ctx, pipeline)
+	if err != nil {
+		return false, err
+	}
+
+	checkpoint, ok := checkpoints[tableName]
+	if !ok {
+		return false, nil
+	}
+
+	applied, err := positionAtOrBefore(record.Position, checkpoint)
+	if err != nil || !applied {
+		return false, err
+	}
+
+	sdk.Logger(ctx).Debug().
+		Str("table", tableName).
+		Str("position", string(record.Position)).
+		Msg("skipping the record at or before the checkpoint")
+
+	return true, nil
+}
+
+// saveCheckpoint updates the checkpoint of the pipeline and table in the transaction of the write.
+func (w *Writer) saveCheckpoint(ctx context.Context, pipeline, tableName string, pos opencdc.Position) error {
+	_, err := w.conn().ExecContext(ctx, fmt.Sprintf(queryMergeCheckpoint, quoteTable(w.checkpointOpts.Table)),
+		pipeline, tableName, []byte(pos))
+	if err != nil {
+		return fmt.Errorf("exec merge checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// pipelineCheckpoints returns the checkpoints of the pipeline's tables, loading them and the reloads
+// in progress on the first call.
+func (w *Writer) pipelineCheckpoints(ctx context.Context, pipeline string) (map[string]opencdc.Position, error) {
+	if checkpoints, ok := w.checkpoints[pipeline]; ok {
+		return checkpoints, nil
+	}
+
+	rows, err := w.db.QueryContext(ctx, fmt.Sprintf(queryGetCheckpoints, quoteTable(w.checkpointOpts.Table)), pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("query checkpoints: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		checkpoints = make(map[string]opencdc.Position)
+		reloads     = make(map[string]bool)
+	)
+
+	for rows.Next() {
+		var (
+			tableName string
+			pos       []byte
+			reloading int
+		)
+
+		if err = rows.Scan(&tableName, &pos, &reloading); err != nil {
+			return nil, fmt.Errorf("scan checkpoint: %w", err)
+		}
+
+		// rows of tables whose reload started before any record was written have no position.
+		if pos != nil {
+			checkpoints[tableName] = pos
+		}
+
+		if reloading != 0 {
+			reloads[tableName] = true
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating checkpoints: %w", err)
+	}
+
+	w.checkpoints[pipeline] = checkpoints
+	w.reloads[pipeline] = reloads
+
+	return checkpoints, nil
+}
+
+// pipelineReloads returns the tables of the pipeline whose reloads are in progress.
+func (w *Writer) pipelineReloads(ctx context.Context, pipeline string) (map[string]bool, error) {
+	if _, err := w.pipelineCheckpoints(ctx, pipeline); err != nil {
+		return nil, err
+	}
+
+	return w.reloads[pipeline], nil
+}
+
+// positionAtOrBefore returns true if the position is equal to the checkpoint or is ordered before it.
+// Positions of the DB2 source are ordered by the source, see [position.Compare], and numeric positions
+// numerically. Positions of another run of the DB2 source, e.g. of a new snapshot, are after the checkpoint.
+// Other positions can't tell if the record was already written, so an [ErrUnorderedPosition] is returned
+// instead of writing the record twice or skipping it.
+func positionAtOrBefore(pos, checkpoint opencdc.Position) (bool, error) {
+	if bytes.Equal(pos, checkpoint) {
+		return true, nil
+	}
+
+	if c, ok := position.Compare(pos, checkpoint); ok {
+		return c <= 0, nil
+	}
+
+	if position.DifferentRuns(pos, checkpoint) {
+		return false, nil
+	}
+
+	if c, ok := compareNumbers(string(pos), string(checkpoint)); ok {
+		return c <= 0, nil
+	}
+
+	return false, fmt.Errorf("position %q, checkpoint %q: %w", pos, checkpoint, ErrUnorderedPosition)
+}
+
+// compareNumbers compares decimal numbers, it returns false if any of the strings is not a number.
+func compareNumbers(a, b string) (int, bool) {
+	ratA, ok := new(big.Rat).SetString(strings.TrimSpace(a))
+	if !ok {
+		return 0, false
+	}
+
+	ratB, ok := new(big.Rat).SetString(strings.TrimSpace(b))
+	if !ok {
+		return 0, false
+	}
+
+	return ratA.Cmp(ratB), true
+}
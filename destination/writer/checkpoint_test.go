@@ -0,0 +1,126 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func Test_positionAtOrBefore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		pos        string
+		checkpoint string
+		want       bool
+		wantErr    error
+	}{
+		{
+			name:       "equal",
+			pos:        "opaque",
+			checkpoint: "opaque",
+			want:       true,
+		},
+		{
+			name:       "unordered",
+			pos:        "a",
+			checkpoint: "b",
+			wantErr:    ErrUnorderedPosition,
+		},
+		{
+			name:       "numeric before",
+			pos:        "99",
+			checkpoint: "100",
+			want:       true,
+		},
+		{
+			name:       "numeric after",
+			pos:        "101",
+			checkpoint: "100",
+			want:       false,
+		},
+		{
+			name:       "snapshot before",
+			pos:        `{"IteratorType":"s","SnapshotLastProcessedVal":9,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+			checkpoint: `{"IteratorType":"s","SnapshotLastProcessedVal":10,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+			want:       true,
+		},
+		{
+			name:       "snapshot after",
+			pos:        `{"IteratorType":"s","SnapshotLastProcessedVal":11,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+			checkpoint: `{"IteratorType":"s","SnapshotLastProcessedVal":10,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+			want:       false,
+		},
+		{
+			name:       "snapshot of another run",
+			pos:        `{"IteratorType":"s","SnapshotLastProcessedVal":9,"SnapshotMaxValue":20,"SuffixName":"100000"}`,
+			checkpoint: `{"IteratorType":"s","SnapshotLastProcessedVal":10,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+			want:       false,
+		},
+		{
+			name:       "snapshot with another max value",
+			pos:        `{"IteratorType":"s","SnapshotLastProcessedVal":9,"SnapshotMaxValue":30,"SuffixName":"213315"}`,
+			checkpoint: `{"IteratorType":"s","SnapshotLastProcessedVal":10,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+			want:       false,
+		},
+		{
+			name:       "snapshot of character values",
+			pos:        `{"IteratorType":"s","SnapshotLastProcessedVal":"a","SnapshotMaxValue":"z","SuffixName":"213315"}`,
+			checkpoint: `{"IteratorType":"s","SnapshotLastProcessedVal":"b","SnapshotMaxValue":"z","SuffixName":"213315"}`,
+			wantErr:    ErrUnorderedPosition,
+		},
+		{
+			name:       "opaque position after a snapshot",
+			pos:        "opaque",
+			checkpoint: `{"IteratorType":"s","SnapshotLastProcessedVal":10,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+			wantErr:    ErrUnorderedPosition,
+		},
+		{
+			name:       "new snapshot after cdc",
+			pos:        `{"IteratorType":"s","SnapshotLastProcessedVal":1,"SnapshotMaxValue":100,"SuffixName":"213315"}`,
+			checkpoint: `{"IteratorType":"c","CDCLastID":1,"SuffixName":"213315"}`,
+			want:       false,
+		},
+		{
+			name:       "cdc before",
+			pos:        `{"IteratorType":"c","CDCLastID":5,"SuffixName":"_213315"}`,
+			checkpoint: `{"IteratorType":"c","CDCLastID":6,"SuffixName":"_213315"}`,
+			want:       true,
+		},
+		{
+			name:       "cdc of another tracking table",
+			pos:        `{"IteratorType":"c","CDCLastID":5,"SuffixName":"_100000"}`,
+			checkpoint: `{"IteratorType":"c","CDCLastID":6,"SuffixName":"_213315"}`,
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			got, err := positionAtOrBefore(opencdc.Position(tt.pos), opencdc.Position(tt.checkpoint))
+			is.True(errors.Is(err, tt.wantErr))
+			is.Equal(got, tt.want)
+		})
+	}
+}
@@ -14,7 +14,11 @@
 
 package writer
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/conduitio-labs/conduit-connector-db2/common"
+)
 
 var (
 	// ErrEmptyPayload occurs when there's no payload to insert.
@@ -25,4 +29,23 @@ var (
 	ErrCompositeKeysNotSupported = errors.New("composite keys not yet supported")
 	// ErrColumnsValuesLenMismatch occurs when trying to insert a row with a different column and value lengths.
 	ErrColumnsValuesLenMismatch = errors.New("number of columns must be equal to number of values")
+	// ErrInvalidIdentifier occurs when a table or column name is not a valid DB2 identifier.
+	ErrInvalidIdentifier = common.ErrInvalidIdentifier
+	// ErrUnknownColumns occurs when the payload contains fields which are not columns of the table.
+	ErrUnknownColumns = errors.New("payload fields are not columns of the table")
+	// ErrDuplicateColumn occurs when several fields are mapped to the same column.
+	ErrDuplicateColumn = errors.New("fields are mapped to the same column")
+	// ErrMissingVersion occurs when the payload of an update doesn't contain the version column.
+	ErrMissingVersion = errors.New("payload doesn't contain the version column")
+	// ErrInconsistentKeys occurs when records of a batch have different key columns.
+	ErrInconsistentKeys = errors.New("records of the batch have different key columns")
+	// ErrDependentObjects occurs when views, triggers or foreign keys prevent replacing a table in the swap reload.
+	ErrDependentObjects = errors.New("views, triggers or foreign keys depend on the table")
+	// ErrTableObjects occurs when indexes or constraints of a table wouldn't be copied to the shadow table
+	// in the swap reload.
+	ErrTableObjects = errors.New("indexes or constraints other than the primary key are defined on the table")
+	// ErrUnorderedPosition occurs when the position of a record can't be ordered against the checkpoint.
+	ErrUnorderedPosition = errors.New("position can't be ordered against the checkpoint")
+	// ErrUnsupportedLiteral occurs when a value can't be written as an SQL literal.
+	ErrUnsupportedLiteral = errors.New("unsupported literal value")
 )
@@ -0,0 +1,212 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/conduitio-labs/conduit-connector-db2/coltypes"
+	"github.com/conduitio-labs/conduit-connector-db2/common"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// SchemaEvolution is a way of handling payload fields which are not columns of the destination table.
+type SchemaEvolution string
+
+const (
+	// SchemaEvolutionStrict fails the record.
+	SchemaEvolutionStrict SchemaEvolution = "strict"
+	// SchemaEvolutionEvolve adds nullable columns to the table.
+	SchemaEvolutionEvolve SchemaEvolution = "evolve"
+	// SchemaEvolutionIgnore drops the fields.
+	SchemaEvolutionIgnore SchemaEvolution = "ignore"
+)
+
+const (
+	queryAddColumn    = `ALTER TABLE %s ADD COLUMN %s %s`
+	queryWidenVarchar = `ALTER TABLE %s ALTER COLUMN %s SET DATA TYPE VARCHAR(%d)`
+
+	// varcharType is a name of the VARCHAR type in the catalog.
+	varcharType = "VARCHAR"
+	// maxVarcharLength is the maximum length of DB2 VARCHAR columns.
+	maxVarcharLength = 32672
+)
+
+// evolveTable handles payload fields which are not columns of the table according to the schema evolution mode
+// and widens VARCHAR columns too short for the payload values if it is enabled.
+// It returns the payload which can be written to the table.
+func (w *Writer) evolveTable(
+	ctx context.Context,
+	tableName string,
+	record opencdc.Record,
+	payload opencdc.StructuredData,
+) (opencdc.StructuredData, error) {
+	tableInfo, err := w.tables.get(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	// the table is unknown, the database reports the error.
+	if len(tableInfo.ColumnTypes) == 0 {
+		return payload, nil
+	}
+
+	unknownFields := make([]string, 0)
+	for field := range payload {
+		if _, ok := tableInfo.ColumnTypes[tableInfo.Column(field)]; !ok {
+			unknownFields = append(unknownFields, field)
+		}
+	}
+
+	sort.Strings(unknownFields)
+
+	var statements []string
+
+	switch w.schemaEvolution {
+	case SchemaEvolutionIgnore:
+		if len(unknownFields) > 0 {
+			filtered := make(opencdc.StructuredData, len(payload))
+			for field, value := range payload {
+				filtered[field] = value
+			}
+
+			for _, field := range unknownFields {
+				delete(filtered, field)
+			}
+
+			payload = filtered
+		}
+
+	case SchemaEvolutionEvolve:
+		statements, err = w.addColumnStatements(ctx, tableName, record, payload, unknownFields)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		if len(unknownFields) > 0 {
+			return nil, fmt.Errorf("table %s, fields %s: %w", tableName,
+				strings.Join(unknownFields, ","), ErrUnknownColumns)
+		}
+	}
+
+	if w.widenVarchar {
+		statements = append(statements, widenVarcharStatements(tableName, tableInfo, payload)...)
+	}
+
+	if len(statements) == 0 {
+		return payload, nil
+	}
+
+	for _, statement := range statements {
+		if _, err = w.conn().ExecContext(ctx, statement); err != nil {
+			return nil, fmt.Errorf("exec %q: %w", statement, err)
+		}
+
+		sdk.Logger(ctx).Info().Str("table", tableName).Str("statement", statement).Msg("evolved destination table")
+	}
+
+	w.tables.invalidate(tableName)
+
+	return payload, nil
+}
+
+// addColumnStatements returns statements adding nullable columns for the fields,
+// with types from the record's schema or inferred from the values.
+func (w *Writer) addColumnStatements(
+	ctx context.Context,
+	tableName string,
+	record opencdc.Record,
+	payload opencdc.StructuredData,
+	fields []string,
+) ([]string, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	if err := common.ValidateIdentifier(tableName); err != nil {
+		return nil, fmt.Errorf("table: %w", err)
+	}
+
+	schemaTypes, err := w.schemaColumnTypes(ctx, record.Metadata.GetPayloadSchemaSubject,
+		record.Metadata.GetPayloadSchemaVersion)
+	if err != nil {
+		return nil, fmt.Errorf("get payload schema column types: %w", err)
+	}
+
+	statements := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if err = common.ValidateIdentifier(field); err != nil {
+			return nil, fmt.Errorf("column: %w", err)
+		}
+
+		dataType, ok := schemaTypes[field]
+		if !ok {
+			dataType = w.inferColumnType(payload[field])
+		}
+
+		statements = append(statements, fmt.Sprintf(queryAddColumn, quoteTable(tableName), quoteColumn(newColumn(field)),
+			dataType))
+	}
+
+	return statements, nil
+}
+
+// widenVarcharStatements returns statements increasing lengths of VARCHAR columns
+// which are too short for the string values of the payload.
+func widenVarcharStatements(tableName string, tableInfo coltypes.TableInfo, payload opencdc.StructuredData) []string {
+	columns := make([]string, 0)
+	lengths := make(map[string]int)
+
+	for field, value := range payload {
+		column := tableInfo.Column(field)
+
+		stringValue, ok := value.(string)
+		if !ok || tableInfo.ColumnTypes[column] != varcharType || tableInfo.ForBitData[column] {
+			continue
+		}
+
+		if len(stringValue) <= tableInfo.ColumnLengths[column] || len(stringValue) > maxVarcharLength {
+			continue
+		}
+
+		columns = append(columns, column)
+		lengths[column] = varcharLength(len(stringValue))
+	}
+
+	sort.Strings(columns)
+
+	statements := make([]string, len(columns))
+	for i, column := range columns {
+		statements[i] = fmt.Sprintf(queryWidenVarchar, quoteTable(tableName), quoteColumn(column), lengths[column])
+	}
+
+	return statements
+}
+
+// varcharLength returns the power of two which fits the length, capped by the maximum VARCHAR length,
+// so that growing values don't alter the column every time.
+func varcharLength(length int) int {
+	newLength := 1
+	for newLength < length {
+		newLength *= 2
+	}
+
+	return min(newLength, maxVarcharLength)
+}
@@ -0,0 +1,117 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/conduitio-labs/conduit-connector-db2/coltypes"
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func testUsersTableInfo() coltypes.TableInfo {
+	return coltypes.TableInfo{
+		ColumnTypes:   map[string]string{"ID": "INTEGER", "NAME": "VARCHAR"},
+		ColumnLengths: map[string]int{"ID": 4, "NAME": 8},
+	}
+}
+
+func TestWriter_evolveTable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		schemaEvolution SchemaEvolution
+		payload         opencdc.StructuredData
+		want            opencdc.StructuredData
+		wantErr         error
+	}{
+		{
+			name:            "strict, known fields",
+			schemaEvolution: SchemaEvolutionStrict,
+			payload:         opencdc.StructuredData{"id": 1, "name": "Alex"},
+			want:            opencdc.StructuredData{"id": 1, "name": "Alex"},
+		},
+		{
+			name:            "strict, unknown field",
+			schemaEvolution: SchemaEvolutionStrict,
+			payload:         opencdc.StructuredData{"id": 1, "email": "alex@example.com"},
+			wantErr:         ErrUnknownColumns,
+		},
+		{
+			name:            "ignore, unknown field",
+			schemaEvolution: SchemaEvolutionIgnore,
+			payload:         opencdc.StructuredData{"id": 1, "email": "alex@example.com"},
+			want:            opencdc.StructuredData{"id": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			w := &Writer{
+				table: "USERS",
+				tables: &tableInfoCache{
+					tables: map[string]coltypes.TableInfo{"USERS": testUsersTableInfo()},
+				},
+				schemaEvolution: tt.schemaEvolution,
+			}
+
+			got, err := w.evolveTable(context.Background(), "USERS", opencdc.Record{}, tt.payload)
+			if tt.wantErr != nil {
+				is.True(errors.Is(err, tt.wantErr))
+
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+func TestWidenVarcharStatements(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	is.Equal(widenVarcharStatements("USERS", testUsersTableInfo(), opencdc.StructuredData{
+		"id":   1,
+		"name": "Alexander the Great",
+	}), []string{`ALTER TABLE "USERS" ALTER COLUMN "NAME" SET DATA TYPE VARCHAR(32)`})
+
+	is.Equal(len(widenVarcharStatements("USERS", testUsersTableInfo(), opencdc.StructuredData{
+		"name": "Alex",
+	})), 0)
+
+	is.Equal(len(widenVarcharStatements("USERS", testUsersTableInfo(), opencdc.StructuredData{
+		"name": strings.Repeat("a", maxVarcharLength+1),
+	})), 0)
+}
+
+func TestVarcharLength(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	is.Equal(varcharLength(9), 16)
+	is.Equal(varcharLength(16), 16)
+	is.Equal(varcharLength(20000), maxVarcharLength)
+}
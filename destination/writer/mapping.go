@@ -0,0 +1,170 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// ColumnCase is a strategy of deriving column names from payload field names.
+type ColumnCase string
+
+const (
+	// ColumnCaseNone uses field names as column names.
+	ColumnCaseNone ColumnCase = "none"
+	// ColumnCaseUpper uppercases field names, e.g. "userId" becomes "USERID".
+	ColumnCaseUpper ColumnCase = "upper"
+	// ColumnCaseSnakeUpper converts field names to upper snake case, e.g. "userId" becomes "USER_ID".
+	ColumnCaseSnakeUpper ColumnCase = "snakeUpper"
+)
+
+// ColumnMapping maps payload and key fields to columns of the destination table.
+type ColumnMapping struct {
+	// Renames - field name with its column name, renamed fields are not affected by Case.
+	Renames map[string]string
+	// Case is a strategy of deriving column names of fields which are not renamed.
+	Case ColumnCase
+	// Include is a list of payload fields which are written, all fields are written if it is empty.
+	Include []string
+	// Exclude is a list of payload fields which are not written.
+	Exclude []string
+}
+
+// column returns the column name of the field.
+func (m ColumnMapping) column(field string) string {
+	if column, ok := m.Renames[field]; ok {
+		return column
+	}
+
+	switch m.Case {
+	case ColumnCaseUpper:
+		return strings.ToUpper(field)
+	case ColumnCaseSnakeUpper:
+		return snakeUpper(field)
+	default:
+		return field
+	}
+}
+
+// isIncluded returns true if the payload field is written according to the include and exclude lists.
+func (m ColumnMapping) isIncluded(field string) bool {
+	if len(m.Include) > 0 && !slices.Contains(m.Include, field) {
+		return false
+	}
+
+	return !slices.Contains(m.Exclude, field)
+}
+
+// mapPayload returns the included payload fields under their column names.
+func (m ColumnMapping) mapPayload(payload opencdc.StructuredData) (opencdc.StructuredData, error) {
+	if payload == nil {
+		return nil, nil
+	}
+
+	mapped := make(opencdc.StructuredData, len(payload))
+	for field, value := range payload {
+		if !m.isIncluded(field) {
+			continue
+		}
+
+		if err := m.set(mapped, field, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return mapped, nil
+}
+
+// mapKey returns the key fields under their column names.
+// Include and exclude lists don't apply to keys, which identify the rows.
+func (m ColumnMapping) mapKey(key opencdc.StructuredData) (opencdc.StructuredData, error) {
+	if key == nil {
+		return nil, nil
+	}
+
+	mapped := make(opencdc.StructuredData, len(key))
+	for field, value := range key {
+		if err := m.set(mapped, field, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return mapped, nil
+}
+
+// mapNames returns the map with its field names replaced by column names,
+// fields mapped to the same column are reported as an error.
+func mapNames[T any](m ColumnMapping, fields map[string]T) (map[string]T, error) {
+	mapped := make(map[string]T, len(fields))
+	for _, field := range slices.Sorted(maps.Keys(fields)) {
+		column := m.column(field)
+		if _, ok := mapped[column]; ok {
+			return nil, fmt.Errorf("field %q, column %q: %w", field, column, ErrDuplicateColumn)
+		}
+
+		mapped[column] = fields[field]
+	}
+
+	return mapped, nil
+}
+
+// set sets the value of the field's column, fields mapped to the same column are reported as an error.
+func (m ColumnMapping) set(data opencdc.StructuredData, field string, value any) error {
+	column := m.column(field)
+	if _, ok := data[column]; ok {
+		return fmt.Errorf("field %q, column %q: %w", field, column, ErrDuplicateColumn)
+	}
+
+	data[column] = value
+
+	return nil
+}
+
+// snakeUpper converts the name to upper snake case. Words are split at lower-to-upper case changes,
+// before the last letter of uppercase acronyms and at characters other than letters and digits,
+// e.g. "userId", "user-id" and "USER_ID" become "USER_ID", and "HTTPServer" becomes "HTTP_SERVER".
+func snakeUpper(name string) string {
+	runes := []rune(name)
+
+	var sb strings.Builder
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			r = '_'
+		}
+
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				sb.WriteRune('_')
+			}
+		}
+
+		if r == '_' && strings.HasSuffix(sb.String(), "_") {
+			continue
+		}
+
+		sb.WriteRune(unicode.ToUpper(r))
+	}
+
+	return sb.String()
+}
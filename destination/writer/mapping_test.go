@@ -0,0 +1,162 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func Test_snakeUpper(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"userId":     "USER_ID",
+		"user_id":    "USER_ID",
+		"user-id":    "USER_ID",
+		"USER_ID":    "USER_ID",
+		"HTTPServer": "HTTP_SERVER",
+		"address2":   "ADDRESS2",
+		"line2Text":  "LINE2_TEXT",
+		"createdAt":  "CREATED_AT",
+		"a  b":       "A_B",
+	}
+
+	for name, want := range tests {
+		name, want := name, want
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			is.Equal(snakeUpper(name), want)
+		})
+	}
+}
+
+func TestColumnMapping_mapPayload(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mapping ColumnMapping
+		payload opencdc.StructuredData
+		want    opencdc.StructuredData
+		wantErr error
+	}{
+		{
+			name:    "no mapping",
+			payload: opencdc.StructuredData{"userId": 1},
+			want:    opencdc.StructuredData{"userId": 1},
+		},
+		{
+			name:    "snake upper with rename",
+			mapping: ColumnMapping{Case: ColumnCaseSnakeUpper, Renames: map[string]string{"mail": "EMAIL"}},
+			payload: opencdc.StructuredData{"userId": 1, "mail": "a@example.com"},
+			want:    opencdc.StructuredData{"USER_ID": 1, "EMAIL": "a@example.com"},
+		},
+		{
+			name:    "include",
+			mapping: ColumnMapping{Case: ColumnCaseUpper, Include: []string{"id", "name"}},
+			payload: opencdc.StructuredData{"id": 1, "name": "alex", "age": 30},
+			want:    opencdc.StructuredData{"ID": 1, "NAME": "alex"},
+		},
+		{
+			name:    "exclude",
+			mapping: ColumnMapping{Exclude: []string{"password"}},
+			payload: opencdc.StructuredData{"id": 1, "password": "secret"},
+			want:    opencdc.StructuredData{"id": 1},
+		},
+		{
+			name:    "duplicate column",
+			mapping: ColumnMapping{Case: ColumnCaseSnakeUpper},
+			payload: opencdc.StructuredData{"userId": 1, "user_id": 1},
+			wantErr: ErrDuplicateColumn,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			got, err := tt.mapping.mapPayload(tt.payload)
+			if tt.wantErr != nil {
+				is.True(errors.Is(err, tt.wantErr))
+
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+func TestColumnMapping_mapKey(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	mapping := ColumnMapping{Case: ColumnCaseSnakeUpper, Exclude: []string{"userId"}}
+
+	got, err := mapping.mapKey(opencdc.StructuredData{"userId": 1})
+	is.NoErr(err)
+	is.Equal(got, opencdc.StructuredData{"USER_ID": 1})
+}
+
+func Test_mapNames(t *testing.T) {
+	t.Parallel()
+
+	mapping := ColumnMapping{Case: ColumnCaseSnakeUpper, Renames: map[string]string{"name": "FULL_NAME"}}
+
+	tests := []struct {
+		name    string
+		fields  map[string]string
+		want    map[string]string
+		wantErr error
+	}{
+		{
+			name:   "mapped names",
+			fields: map[string]string{"userId": "BIGINT", "name": "VARCHAR(100)"},
+			want:   map[string]string{"USER_ID": "BIGINT", "FULL_NAME": "VARCHAR(100)"},
+		},
+		{
+			name:    "fields of the same column",
+			fields:  map[string]string{"userId": "BIGINT", "user_id": "INTEGER"},
+			wantErr: ErrDuplicateColumn,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			got, err := mapNames(mapping, tt.fields)
+			if tt.wantErr != nil {
+				is.True(errors.Is(err, tt.wantErr))
+
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-db2/coltypes"
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// WriteMode is a way of writing records to the destination table.
+type WriteMode string
+
+const (
+	// WriteModeStandard inserts, updates and deletes rows.
+	WriteModeStandard WriteMode = "standard"
+	// WriteModeSoftDelete inserts and updates rows, and marks deleted rows in a column instead of deleting them.
+	WriteModeSoftDelete WriteMode = "softDelete"
+	// WriteModeAppendOnly inserts every record as a new row with its operation, position and timestamp.
+	WriteModeAppendOnly WriteMode = "appendOnly"
+	// WriteModeSCD2 keeps the history of rows as a slowly changing dimension type 2.
+	WriteModeSCD2 WriteMode = "scd2"
+)
+
+// SoftDeleteOptions are options of the soft-delete write mode.
+type SoftDeleteOptions struct {
+	// Column is a name of the column marking deleted rows.
+	Column string
+	// Flag sets the column to 1 instead of the deletion timestamp.
+	Flag bool
+}
+
+// AppendColumns are names of the columns written by the append-only write mode.
+type AppendColumns struct {
+	// Operation is a name of the column with the operation of the record.
+	Operation string
+	// Position is a name of the column with the position of the record.
+	Position string
+	// Timestamp is a name of the column with the time the record was read at.
+	Timestamp string
+}
+
+// softDelete marks the row deleted by the record.
+func (w *Writer) softDelete(ctx context.Context, record opencdc.Record) error {
+	tableName, err := w.resolveTable(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	return w.write(ctx, record, tableName, func() error {
+		tableInfo, keys, err := w.deleteKeys(ctx, tableName, record)
+		if err != nil {
+			return err
+		}
+
+		var value any = recordTime(record)
+		if w.softDeleteOpts.Flag {
+			value = int64(1)
+		}
+
+		values, err := coltypes.ConvertStructureData(ctx, tableInfo,
+			opencdc.StructuredData{w.softDeleteOpts.Column: value}, w.convertOpts)
+		if err != nil {
+			return fmt.Errorf("convert soft-delete column: %w", err)
+		}
+
+		query, args := w.buildUpdateQuery(tableName, keys, values)
+
+		_, err = w.conn().ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("exec soft delete: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// appendRecord inserts the record as a new row. The row contains the payload after the change,
+// or before the change for deletes, the key fields missing in the payload, and the operation,
+// position and timestamp of the record. Created tables don't have a primary key.
+func (w *Writer) appendRecord(ctx context.Context, record opencdc.Record) error {
+	tableName, err := w.getTableName(record)
+	if err != nil {
+		return err
+	}
+
+	return w.write(ctx, record, tableName, func() error {
+		data := record.Payload.After
+		if record.Operation == opencdc.OperationDelete {
+			data = record.Payload.Before
+		}
+
+		payload, err := w.structurizeData(data)
+		if err != nil {
+			return fmt.Errorf("structurize payload: %w", err)
+		}
+
+		payload, err = w.columnMapping.mapPayload(payload)
+		if err != nil {
+			return fmt.Errorf("map payload: %w", err)
+		}
+
+		if payload == nil {
+			payload = make(opencdc.StructuredData)
+		}
+
+		// scalar keys can't be matched to columns, so they are written only as a part of the payload.
+		keys, _ := w.structurizeData(record.Key)
+
+		keys, err = w.columnMapping.mapKey(keys)
+		if err != nil {
+			return fmt.Errorf("map key: %w", err)
+		}
+
+		for column, value := range keys {
+			if _, ok := payloadField(payload, column); !ok {
+				payload[column] = value
+			}
+		}
+
+		payload[w.appendColumns.Operation] = record.Operation.String()
+		payload[w.appendColumns.Position] = string(record.Position)
+		payload[w.appendColumns.Timestamp] = recordTime(record)
+
+		if err = w.prepareTable(ctx, tableName, record, nil, payload); err != nil {
+			return fmt.Errorf("prepare table: %w", err)
+		}
+
+		return w.insertRow(ctx, tableName, record, payload)
+	})
+}
+
+// modeColumnTypes returns types of the columns written by the write mode, which are used for created tables.
+func (w *Writer) modeColumnTypes() map[string]string {
+	switch w.writeMode {
+	case WriteModeSoftDelete:
+		if w.softDeleteOpts.Flag {
+			return map[string]string{w.softDeleteOpts.Column: w.typeMapping[KindBoolean]}
+		}
+
+		return map[string]string{w.softDeleteOpts.Column: w.typeMapping[KindTimestamp]}
+
+	case WriteModeAppendOnly:
+		return map[string]string{
+			w.appendColumns.Operation: w.typeMapping[KindKeyString],
+			w.appendColumns.Position:  w.typeMapping[KindString],
+			w.appendColumns.Timestamp: w.typeMapping[KindTimestamp],
+		}
+
+	case WriteModeSCD2:
+		return map[string]string{
+			w.scd2Columns.ValidFrom: w.typeMapping[KindTimestamp],
+			w.scd2Columns.ValidTo:   w.typeMapping[KindTimestamp],
+			w.scd2Columns.Current:   w.typeMapping[KindInt],
+		}
+
+	default:
+		return nil
+	}
+}
+
+// recordTime returns the time the record was read at, or the current time if the record doesn't have it.
+func recordTime(record opencdc.Record) time.Time {
+	readAt, err := record.Metadata.GetReadAt()
+	if err != nil || readAt.IsZero() {
+		return time.Now()
+	}
+
+	return readAt
+}
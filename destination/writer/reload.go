@@ -0,0 +1,514 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/conduitio-labs/conduit-connector-db2/common"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// ReloadMode is a way of removing stale rows from the destination table when a new snapshot starts.
+type ReloadMode string
+
+const (
+	// ReloadModeNone writes snapshot records over the existing rows.
+	ReloadModeNone ReloadMode = "none"
+	// ReloadModeTruncate truncates the table on the first snapshot record of a new snapshot.
+	// The truncation isn't atomic, readers see the table partially loaded until the snapshot ends.
+	ReloadModeTruncate ReloadMode = "truncate"
+	// ReloadModeSwap loads the snapshot into a shadow table, which replaces the table
+	// when the snapshot ends.
+	ReloadModeSwap ReloadMode = "swap"
+)
+
+const (
+	// reloadPrefix is a prefix of shadow table names.
+	reloadPrefix = "CONDUIT_RELOAD_"
+	// replacedPrefix is a prefix of the names of replaced tables, which are dropped in the same transaction.
+	replacedPrefix = "CONDUIT_REPLACED_"
+
+	queryTruncateTable    = `TRUNCATE TABLE %s IMMEDIATE`
+	queryCreateShadow     = `CREATE TABLE %s LIKE %s INCLUDING COLUMN DEFAULTS INCLUDING IDENTITY COLUMN ATTRIBUTES`
+	queryAddPrimaryKey    = `ALTER TABLE %s ADD PRIMARY KEY (%s)`
+	queryRenameTable      = `RENAME TABLE %s TO %s`
+	queryDropTable        = `DROP TABLE %s`
+	queryDeleteCheckpoint = `DELETE FROM %s WHERE PIPELINE = ? AND TABLE_NAME = ?`
+
+	// queryMoveCheckpoint sets the position of the table's checkpoint to the position of the shadow table.
+	queryMoveCheckpoint = `
+	UPDATE %[1]s SET
+		POSITION = (SELECT S.POSITION FROM %[1]s AS S WHERE S.PIPELINE = ? AND S.TABLE_NAME = ?),
+		UPDATED_AT = CURRENT TIMESTAMP
+	WHERE PIPELINE = ? AND TABLE_NAME = ?`
+
+	// queryCountDependents counts views, materialized query tables, triggers and foreign keys
+	// which depend on the table and prevent renaming it.
+	queryCountDependents = `
+	SELECT
+		(SELECT COUNT(*) FROM SYSCAT.TABDEP WHERE BSCHEMA = CURRENT SCHEMA AND BNAME = ?) +
+		(SELECT COUNT(*) FROM SYSCAT.TRIGGERS WHERE TABSCHEMA = CURRENT SCHEMA AND TABNAME = ?) +
+		(SELECT COUNT(*) FROM SYSCAT.REFERENCES WHERE REFTABSCHEMA = CURRENT SCHEMA AND REFTABNAME = ?)
+	FROM SYSIBM.SYSDUMMY1`
+
+	// queryCountTableObjects counts indexes and constraints of the table other than its primary key,
+	// which CREATE TABLE ... LIKE doesn't copy to the shadow table.
+	queryCountTableObjects = `
+	SELECT
+		(SELECT COUNT(*) FROM SYSCAT.INDEXES WHERE TABSCHEMA = CURRENT SCHEMA AND TABNAME = ? AND UNIQUERULE <> 'P') +
+		(SELECT COUNT(*) FROM SYSCAT.TABCONST WHERE TABSCHEMA = CURRENT SCHEMA AND TABNAME = ? AND TYPE <> 'P')
+	FROM SYSIBM.SYSDUMMY1`
+)
+
+// resolveTable returns the table the record is written to. If the reload mode is set, the first snapshot record
+// of a new snapshot starts the reload of the table, and the first record after the snapshot finishes it,
+// unless the last snapshot record already finished it.
+func (w *Writer) resolveTable(ctx context.Context, record opencdc.Record) (string, error) {
+	tableName, err := w.getTableName(record)
+	if err != nil || !w.isReloading() {
+		return tableName, err
+	}
+
+	target, reloading := w.reloading[tableName]
+
+	switch {
+	case record.Operation == opencdc.OperationSnapshot && reloading:
+		return target, nil
+
+	case record.Operation == opencdc.OperationSnapshot:
+		target, err = w.startReload(ctx, record, tableName)
+		if err != nil {
+			return "", fmt.Errorf("start reload of %q: %w", tableName, err)
+		}
+
+		w.reloading[tableName] = target
+
+		return target, nil
+
+	case reloading:
+		if err = w.finishReload(ctx, record, tableName, target); err != nil {
+			return "", fmt.Errorf("finish reload of %q: %w", tableName, err)
+		}
+
+		delete(w.reloading, tableName)
+
+	default:
+		if err = w.finishInterruptedReload(ctx, record, tableName); err != nil {
+			return "", fmt.Errorf("finish interrupted reload of %q: %w", tableName, err)
+		}
+	}
+
+	return tableName, nil
+}
+
+// finishCompletedSnapshot finishes the reload of the table after the last record of its snapshot is written,
+// which the DB2 source marks with the snapshot completion metadata, so the reload doesn't wait for a record
+// after the snapshot, which never arrives if the source only takes a snapshot.
+func (w *Writer) finishCompletedSnapshot(ctx context.Context, record opencdc.Record) error {
+	if !w.isReloading() || record.Operation != opencdc.OperationSnapshot ||
+		record.Metadata[metadataSnapshotCompleted] != "true" {
+		return nil
+	}
+
+	tableName, err := w.getTableName(record)
+	if err != nil {
+		return err
+	}
+
+	target, ok := w.reloading[tableName]
+	if !ok {
+		return nil
+	}
+
+	if err = w.finishReload(ctx, record, tableName, target); err != nil {
+		return fmt.Errorf("finish reload of %q: %w", tableName, err)
+	}
+
+	delete(w.reloading, tableName)
+
+	return nil
+}
+
+// finishInterruptedReload finishes the reload in progress of a table whose snapshot ended while the connector
+// was stopped, e.g. by a restart before the first record after the snapshot, replacing the table with
+// its shadow table in the swap mode. Tables are checked once.
+func (w *Writer) finishInterruptedReload(ctx context.Context, record opencdc.Record, tableName string) error {
+	if w.checkpointOpts.Table == "" || w.reloadsChecked[tableName] {
+		return nil
+	}
+
+	reloading, err := w.isReloadInProgress(ctx, record, tableName)
+	if err != nil {
+		return err
+	}
+
+	if reloading {
+		target := tableName
+
+		if w.reloadMode == ReloadModeSwap {
+			shadow, er := shadowTable(tableName)
+			if er != nil {
+				return er
+			}
+
+			shadowInfo, er := w.tables.get(ctx, shadow)
+			if er != nil {
+				return er
+			}
+
+			if len(shadowInfo.ColumnTypes) > 0 {
+				target = shadow
+			}
+		}
+
+		sdk.Logger(ctx).Info().Str("table", tableName).Msg("finishing the reload interrupted after its snapshot")
+
+		if err = w.finishReload(ctx, record, tableName, target); err != nil {
+			return err
+		}
+	}
+
+	w.reloadsChecked[tableName] = true
+
+	return nil
+}
+
+// isReloading returns true if tables are reloaded when a new snapshot starts.
+func (w *Writer) isReloading() bool {
+	return w.reloadMode == ReloadModeTruncate || w.reloadMode == ReloadModeSwap
+}
+
+// startReload prepares the table for a new snapshot and returns the table the snapshot is written to.
+// Tables which don't exist yet are written directly.
+func (w *Writer) startReload(ctx context.Context, record opencdc.Record, tableName string) (string, error) {
+	tableInfo, err := w.tables.get(ctx, tableName)
+	if err != nil {
+		return "", err
+	}
+
+	if len(tableInfo.ColumnTypes) == 0 {
+		return tableName, nil
+	}
+
+	// the reload of a table whose previous snapshot didn't end is still in progress,
+	// so the source resumed the interrupted snapshot instead of starting a new one.
+	resumed, err := w.isReloadInProgress(ctx, record, tableName)
+	if err != nil {
+		return "", err
+	}
+
+	// the table is checked once, the reload in progress is finished by the snapshot.
+	w.reloadsChecked[tableName] = true
+
+	if w.reloadMode == ReloadModeTruncate {
+		if resumed {
+			sdk.Logger(ctx).Info().Str("table", tableName).Msg("resuming reload of the table")
+
+			return tableName, nil
+		}
+
+		return tableName, w.truncateTable(ctx, record, tableName)
+	}
+
+	shadow, err := shadowTable(tableName)
+	if err != nil {
+		return "", err
+	}
+
+	shadowInfo, err := w.tables.get(ctx, shadow)
+	if err != nil {
+		return "", err
+	}
+
+	if len(shadowInfo.ColumnTypes) > 0 {
+		// the shadow table of an interrupted reload is kept if the snapshot is resumed,
+		// so the reload continues where it stopped, and it is stale if a new snapshot started.
+		if resumed {
+			sdk.Logger(ctx).Info().Str("table", tableName).Msg("resuming reload into the existing shadow table")
+
+			return shadow, nil
+		}
+
+		if _, err = w.db.ExecContext(ctx, fmt.Sprintf(queryDropTable, quoteTable(shadow))); err != nil {
+			return "", fmt.Errorf("exec drop stale shadow table: %w", err)
+		}
+
+		w.tables.invalidate(shadow)
+
+		sdk.Logger(ctx).Info().Str("table", tableName).Msg("dropped the shadow table of a previous snapshot")
+	}
+
+	if err = w.checkDependents(ctx, tableName); err != nil {
+		return "", err
+	}
+
+	if err = w.createShadowTable(ctx, tableName, shadow, tableInfo.PrimaryKeys); err != nil {
+		return "", err
+	}
+
+	// checkpoints of a previous reload don't apply to the new shadow table.
+	if err = w.deleteCheckpoint(ctx, record, shadow); err != nil {
+		return "", err
+	}
+
+	if err = w.setReloading(ctx, record, tableName, true); err != nil {
+		return "", err
+	}
+
+	sdk.Logger(ctx).Info().Str("table", tableName).Str("shadow", shadow).Msg("started reload into a shadow table")
+
+	return shadow, nil
+}
+
+// shadowTable returns the name of the shadow table of the table.
+func shadowTable(tableName string) (string, error) {
+	if len(replacedPrefix)+len(tableName) > maxIdentifierLength ||
+		len(reloadPrefix)+len(tableName) > maxIdentifierLength {
+		return "", fmt.Errorf("shadow table of %q: %w", tableName, ErrInvalidIdentifier)
+	}
+
+	return reloadPrefix + tableName, nil
+}
+
+// checkDependents returns an error if views, triggers or foreign keys depend on the table, which prevent
+// replacing it with the shadow table when the snapshot ends, or if the table has indexes or constraints
+// which the shadow table wouldn't have.
+func (w *Writer) checkDependents(ctx context.Context, tableName string) error {
+	var count int
+	if err := w.db.QueryRowContext(ctx, queryCountDependents, tableName, tableName, tableName).
+		Scan(&count); err != nil {
+		return fmt.Errorf("query dependents of %q: %w", tableName, err)
+	}
+
+	if count > 0 {
+		return fmt.Errorf("%q: %w", tableName, ErrDependentObjects)
+	}
+
+	if err := w.db.QueryRowContext(ctx, queryCountTableObjects, tableName, tableName).Scan(&count); err != nil {
+		return fmt.Errorf("query indexes and constraints of %q: %w", tableName, err)
+	}
+
+	if count > 0 {
+		return fmt.Errorf("%q: %w", tableName, ErrTableObjects)
+	}
+
+	return nil
+}
+
+// truncateTable truncates the table for a new snapshot. The reload is marked as in progress before the truncation,
+// so a snapshot resumed after a restart doesn't truncate the table again. TRUNCATE IMMEDIATE can't be rolled back,
+// so it is executed in its own unit of work.
+func (w *Writer) truncateTable(ctx context.Context, record opencdc.Record, tableName string) error {
+	if err := w.deleteCheckpoint(ctx, record, tableName); err != nil {
+		return err
+	}
+
+	if err := w.setReloading(ctx, record, tableName, true); err != nil {
+		return err
+	}
+
+	if _, err := w.db.ExecContext(ctx, fmt.Sprintf(queryTruncateTable, quoteTable(tableName))); err != nil {
+		return fmt.Errorf("exec truncate table: %w", err)
+	}
+
+	sdk.Logger(ctx).Info().Str("table", tableName).Msg("truncated table for a new snapshot")
+
+	return nil
+}
+
+// createShadowTable creates the shadow table with the columns, their defaults and identity attributes,
+// and the primary key of the table.
+func (w *Writer) createShadowTable(ctx context.Context, tableName, shadow string, primaryKeys []string) error {
+	if _, err := w.db.ExecContext(ctx,
+		fmt.Sprintf(queryCreateShadow, quoteTable(shadow), quoteTable(tableName))); err != nil {
+		return fmt.Errorf("exec create shadow table: %w", err)
+	}
+
+	if len(primaryKeys) > 0 {
+		query := fmt.Sprintf(queryAddPrimaryKey, quoteTable(shadow), strings.Join(common.QuoteIdentifiers(primaryKeys), ", "))
+		if _, err := w.db.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("exec add primary key to shadow table: %w", err)
+		}
+	}
+
+	w.tables.invalidate(shadow)
+
+	return nil
+}
+
+// finishReload ends the reload of the table, replacing the table with its shadow table in the transaction
+// which ends the reload, so readers see either the previous rows or the whole snapshot.
+func (w *Writer) finishReload(ctx context.Context, record opencdc.Record, tableName, target string) error {
+	if target == tableName {
+		return w.setReloading(ctx, record, tableName, false)
+	}
+
+	replaced := replacedPrefix + tableName
+
+	err := w.inTransaction(ctx, func() error {
+		for _, query := range []string{
+			fmt.Sprintf(queryRenameTable, quoteTable(tableName), quoteTable(replaced)),
+			fmt.Sprintf(queryRenameTable, quoteTable(target), quoteTable(tableName)),
+			fmt.Sprintf(queryDropTable, quoteTable(replaced)),
+		} {
+			if _, err := w.conn().ExecContext(ctx, query); err != nil {
+				return fmt.Errorf("exec swap tables: %w", err)
+			}
+		}
+
+		// the rows of the table are the rows of the shadow table now, and so is their checkpoint.
+		if err := w.moveCheckpoint(ctx, record, target, tableName); err != nil {
+			return err
+		}
+
+		return w.setReloading(ctx, record, tableName, false)
+	})
+	if err != nil {
+		return err
+	}
+
+	w.tables.invalidate(tableName)
+	w.tables.invalidate(target)
+
+	sdk.Logger(ctx).Info().Str("table", tableName).Msg("replaced table with the reloaded shadow table")
+
+	return nil
+}
+
+// isReloadInProgress returns true if the reload of the table was started and not finished in the pipeline
+// of the record. Without checkpoints no reload is in progress.
+func (w *Writer) isReloadInProgress(ctx context.Context, record opencdc.Record, tableName string) (bool, error) {
+	if w.checkpointOpts.Table == "" {
+		return false, nil
+	}
+
+	pipeline, err := w.checkpointPipeline(record)
+	if err != nil {
+		return false, err
+	}
+
+	reloads, err := w.pipelineReloads(ctx, pipeline)
+	if err != nil {
+		return false, err
+	}
+
+	return reloads[tableName], nil
+}
+
+// setReloading marks the reload of the table as in progress, or as finished, in the checkpoint table,
+// in the transaction of the record being written if there is one.
+func (w *Writer) setReloading(ctx context.Context, record opencdc.Record, tableName string, reloading bool) error {
+	if w.checkpointOpts.Table == "" {
+		return nil
+	}
+
+	pipeline, err := w.checkpointPipeline(record)
+	if err != nil {
+		return err
+	}
+
+	reloads, err := w.pipelineReloads(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+
+	flag := 0
+	if reloading {
+		flag = 1
+	}
+
+	query := fmt.Sprintf(queryMergeReloading, quoteTable(w.checkpointOpts.Table))
+	if _, err = w.conn().ExecContext(ctx, query, pipeline, tableName, flag); err != nil {
+		return fmt.Errorf("exec merge reloading: %w", err)
+	}
+
+	w.afterCommit(func() {
+		if reloading {
+			reloads[tableName] = true
+		} else {
+			delete(reloads, tableName)
+		}
+	})
+
+	return nil
+}
+
+// deleteCheckpoint deletes the checkpoint of the table, so records of the new snapshot aren't skipped.
+func (w *Writer) deleteCheckpoint(ctx context.Context, record opencdc.Record, tableName string) error {
+	if w.checkpointOpts.Table == "" {
+		return nil
+	}
+
+	pipeline, err := w.checkpointPipeline(record)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(queryDeleteCheckpoint, quoteTable(w.checkpointOpts.Table))
+	if _, err = w.db.ExecContext(ctx, query, pipeline, tableName); err != nil {
+		return fmt.Errorf("exec delete checkpoint: %w", err)
+	}
+
+	delete(w.checkpoints[pipeline], tableName)
+
+	return nil
+}
+
+// moveCheckpoint replaces the checkpoint of the table with the checkpoint of the shadow table
+// in the transaction of the swap, and deletes the checkpoint of the shadow table.
+func (w *Writer) moveCheckpoint(ctx context.Context, record opencdc.Record, shadow, tableName string) error {
+	if w.checkpointOpts.Table == "" {
+		return nil
+	}
+
+	pipeline, err := w.checkpointPipeline(record)
+	if err != nil {
+		return err
+	}
+
+	checkpoints, err := w.pipelineCheckpoints(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+
+	checkpointTable := quoteTable(w.checkpointOpts.Table)
+
+	_, err = w.conn().ExecContext(ctx, fmt.Sprintf(queryMoveCheckpoint, checkpointTable),
+		pipeline, shadow, pipeline, tableName)
+	if err != nil {
+		return fmt.Errorf("exec move checkpoint: %w", err)
+	}
+
+	_, err = w.conn().ExecContext(ctx, fmt.Sprintf(queryDeleteCheckpoint, checkpointTable), pipeline, shadow)
+	if err != nil {
+		return fmt.Errorf("exec delete checkpoint: %w", err)
+	}
+
+	w.afterCommit(func() {
+		if pos, ok := checkpoints[shadow]; ok {
+			checkpoints[tableName] = pos
+		} else {
+			delete(checkpoints, tableName)
+		}
+
+		delete(checkpoints, shadow)
+	})
+
+	return nil
+}
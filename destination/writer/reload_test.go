@@ -0,0 +1,170 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestWriter_resolveTable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		reloadMode    ReloadMode
+		reloading     map[string]string
+		operation     opencdc.Operation
+		want          string
+		wantReloading map[string]string
+	}{
+		{
+			name:          "no reload",
+			reloadMode:    ReloadModeNone,
+			operation:     opencdc.OperationSnapshot,
+			want:          "USERS",
+			wantReloading: map[string]string{},
+		},
+		{
+			name:          "snapshot record during reload",
+			reloadMode:    ReloadModeSwap,
+			reloading:     map[string]string{"USERS": "CONDUIT_RELOAD_USERS"},
+			operation:     opencdc.OperationSnapshot,
+			want:          "CONDUIT_RELOAD_USERS",
+			wantReloading: map[string]string{"USERS": "CONDUIT_RELOAD_USERS"},
+		},
+		{
+			name:          "first record after the snapshot of a new table",
+			reloadMode:    ReloadModeSwap,
+			reloading:     map[string]string{"USERS": "USERS"},
+			operation:     opencdc.OperationUpdate,
+			want:          "USERS",
+			wantReloading: map[string]string{},
+		},
+		{
+			name:          "record without reload",
+			reloadMode:    ReloadModeTruncate,
+			operation:     opencdc.OperationCreate,
+			want:          "USERS",
+			wantReloading: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			w := &Writer{table: "USERS", reloadMode: tt.reloadMode, reloading: map[string]string{}}
+			for table, target := range tt.reloading {
+				w.reloading[table] = target
+			}
+
+			got, err := w.resolveTable(context.Background(), opencdc.Record{
+				Operation: tt.operation,
+				Metadata:  opencdc.Metadata{},
+			})
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+			is.Equal(w.reloading, tt.wantReloading)
+		})
+	}
+}
+
+func TestWriter_finishCompletedSnapshot(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		operation     opencdc.Operation
+		metadata      opencdc.Metadata
+		wantReloading map[string]string
+	}{
+		{
+			name:          "last snapshot record",
+			operation:     opencdc.OperationSnapshot,
+			metadata:      opencdc.Metadata{metadataSnapshotCompleted: "true"},
+			wantReloading: map[string]string{},
+		},
+		{
+			name:          "snapshot record",
+			operation:     opencdc.OperationSnapshot,
+			metadata:      opencdc.Metadata{},
+			wantReloading: map[string]string{"USERS": "USERS"},
+		},
+		{
+			name:          "marked record which is not a snapshot record",
+			operation:     opencdc.OperationCreate,
+			metadata:      opencdc.Metadata{metadataSnapshotCompleted: "true"},
+			wantReloading: map[string]string{"USERS": "USERS"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			w := &Writer{table: "USERS", reloadMode: ReloadModeSwap, reloading: map[string]string{"USERS": "USERS"}}
+
+			err := w.finishCompletedSnapshot(context.Background(), opencdc.Record{
+				Operation: tt.operation,
+				Metadata:  tt.metadata,
+			})
+			is.NoErr(err)
+			is.Equal(w.reloading, tt.wantReloading)
+		})
+	}
+}
+
+func TestWriter_sameReloadGroup(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	snapshot := opencdc.Record{Operation: opencdc.OperationSnapshot}
+	create := opencdc.Record{Operation: opencdc.OperationCreate}
+
+	is.True((&Writer{reloadMode: ReloadModeNone}).sameReloadGroup(snapshot, create))
+	is.True((&Writer{reloadMode: ReloadModeSwap}).sameReloadGroup(snapshot, snapshot))
+	is.True(!(&Writer{reloadMode: ReloadModeSwap}).sameReloadGroup(snapshot, create))
+}
+
+func TestWriter_isReloadInProgress(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	record := opencdc.Record{Operation: opencdc.OperationSnapshot, Position: opencdc.Position("opaque")}
+
+	w := &Writer{
+		checkpointOpts: Checkpoints{Table: "CONDUIT_CHECKPOINTS", Pipeline: "orders"},
+		checkpoints:    map[string]map[string]opencdc.Position{"orders": {}},
+		reloads:        map[string]map[string]bool{"orders": {"USERS": true}},
+	}
+
+	got, err := w.isReloadInProgress(context.Background(), record, "USERS")
+	is.NoErr(err)
+	is.True(got)
+
+	got, err = w.isReloadInProgress(context.Background(), record, "ORDERS")
+	is.NoErr(err)
+	is.True(!got)
+
+	got, err = (&Writer{}).isReloadInProgress(context.Background(), record, "USERS")
+	is.NoErr(err)
+	is.True(!got)
+}
@@ -0,0 +1,143 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/conduitio-labs/conduit-connector-db2/common"
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// tableTemplateFuncs are functions available in table name templates.
+var tableTemplateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	// replace takes the string last, so it can be used in pipelines.
+	"replace": func(old, replacement, s string) string {
+		return strings.ReplaceAll(s, old, replacement)
+	},
+}
+
+// tableTemplateData is the data table name templates are executed with.
+type tableTemplateData struct {
+	// Metadata is the metadata of the record.
+	Metadata opencdc.Metadata
+	// Key is the structured record key, or the value of a scalar key.
+	Key any
+	// Payload is the payload after the change, or before the change for deletes.
+	Payload opencdc.StructuredData
+	// Operation is the operation of the record.
+	Operation string
+}
+
+// IsTableTemplate returns true if the table name is a Go template.
+func IsTableTemplate(table string) bool {
+	return strings.Contains(table, "{{")
+}
+
+// ParseTableTemplate parses the table name template.
+func ParseTableTemplate(table string) (*template.Template, error) {
+	tmpl, err := template.New("table").Funcs(tableTemplateFuncs).Option("missingkey=zero").Parse(table)
+	if err != nil {
+		return nil, fmt.Errorf("parse table template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// getTableName returns either the records metadata value for table,
+// the table name rendered from the template or the default configured value for table.
+func (w *Writer) getTableName(record opencdc.Record) (string, error) {
+	if tableName, ok := record.Metadata[metadataTable]; ok {
+		tableName = common.NormalizeIdentifier(tableName)
+		if err := common.ValidateIdentifier(tableName); err != nil {
+			return "", fmt.Errorf("metadata table: %w", err)
+		}
+
+		return tableName, nil
+	}
+
+	if w.tableTemplate == nil {
+		return w.table, nil
+	}
+
+	return w.renderTableName(record)
+}
+
+// renderTableName executes the table template with the record. The rendered name is folded to uppercase,
+// the way DB2 folds ordinary identifiers, and must be a valid ordinary identifier.
+func (w *Writer) renderTableName(record opencdc.Record) (string, error) {
+	data := tableTemplateData{
+		Metadata:  record.Metadata,
+		Operation: record.Operation.String(),
+	}
+
+	if key, err := w.structurizeData(record.Key); err == nil {
+		data.Key = key
+	} else {
+		data.Key = scalarKey(record.Key)
+	}
+
+	payload := record.Payload.After
+	if record.Operation == opencdc.OperationDelete {
+		payload = record.Payload.Before
+	}
+
+	// the table can be rendered from metadata or key only, so invalid payloads are reported by the write itself.
+	data.Payload, _ = w.structurizeData(payload)
+
+	var sb strings.Builder
+	if err := w.tableTemplate.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("execute table template: %w", err)
+	}
+
+	tableName := strings.ToUpper(strings.TrimSpace(sb.String()))
+	if !identifierRegexp.MatchString(tableName) {
+		return "", fmt.Errorf("rendered table %q: %w", tableName, ErrInvalidIdentifier)
+	}
+
+	return tableName, nil
+}
+
+// quoteTable returns the table name as a delimited identifier. Table names are normalized when they are configured,
+// rendered or read from the record metadata, so they are quoted exactly.
+func quoteTable(table string) string {
+	return common.QuoteIdentifier(table)
+}
+
+// quoteColumn returns the column as a delimited identifier. Fields are resolved to the columns of the table
+// when they are converted, see coltypes.TableInfo.Column, so the name is quoted exactly.
+func quoteColumn(column string) string {
+	return common.QuoteIdentifier(column)
+}
+
+// newColumn returns the name of the column created for a payload or key field,
+// which is folded to uppercase, the way DB2 folds ordinary identifiers.
+func newColumn(field string) string {
+	return strings.ToUpper(field)
+}
+
+// quoteColumns returns the columns as delimited identifiers.
+func quoteColumns(columns []string) []string {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = quoteColumn(column)
+	}
+
+	return quoted
+}
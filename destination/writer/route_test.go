@@ -0,0 +1,133 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestWriter_getTableName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		table   string
+		record  opencdc.Record
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "configured table",
+			table:  "USERS",
+			record: opencdc.Record{Metadata: opencdc.Metadata{}},
+			want:   "USERS",
+		},
+		{
+			name:   "metadata table",
+			table:  `STAGE_{{ index .Metadata "opencdc.collection" }}`,
+			record: opencdc.Record{Metadata: opencdc.Metadata{metadataTable: "CLIENTS"}},
+			want:   "CLIENTS",
+		},
+		{
+			name:   "collection template",
+			table:  `STAGE_{{ index .Metadata "opencdc.collection" }}`,
+			record: opencdc.Record{Metadata: opencdc.Metadata{"opencdc.collection": "orders"}},
+			want:   "STAGE_ORDERS",
+		},
+		{
+			name:  "payload template",
+			table: `{{ .Payload.kind | replace "-" "_" }}_EVENTS`,
+			record: opencdc.Record{
+				Operation: opencdc.OperationCreate,
+				Payload:   opencdc.Change{After: opencdc.RawData(`{"kind":"page-view"}`)},
+			},
+			want: "PAGE_VIEW_EVENTS",
+		},
+		{
+			name:  "delete payload template",
+			table: `{{ .Payload.kind }}`,
+			record: opencdc.Record{
+				Operation: opencdc.OperationDelete,
+				Payload:   opencdc.Change{Before: opencdc.StructuredData{"kind": "clicks"}},
+			},
+			want: "CLICKS",
+		},
+		{
+			name:   "scalar key template",
+			table:  `T_{{ .Key }}`,
+			record: opencdc.Record{Key: opencdc.RawData("42")},
+			want:   "T_42",
+		},
+		{
+			name:    "injection",
+			table:   `{{ index .Metadata "opencdc.collection" }}`,
+			record:  opencdc.Record{Metadata: opencdc.Metadata{"opencdc.collection": "users; DROP TABLE users"}},
+			wantErr: ErrInvalidIdentifier,
+		},
+		{
+			name:    "empty metadata table",
+			table:   "USERS",
+			record:  opencdc.Record{Metadata: opencdc.Metadata{metadataTable: `""`}},
+			wantErr: ErrInvalidIdentifier,
+		},
+		{
+			name:    "empty",
+			table:   `{{ index .Metadata "opencdc.collection" }}`,
+			record:  opencdc.Record{Metadata: opencdc.Metadata{}},
+			wantErr: ErrInvalidIdentifier,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			w := &Writer{table: tt.table}
+			if IsTableTemplate(tt.table) {
+				tmpl, err := ParseTableTemplate(tt.table)
+				is.NoErr(err)
+
+				w.tableTemplate = tmpl
+			}
+
+			got, err := w.getTableName(tt.record)
+			if tt.wantErr != nil {
+				is.True(errors.Is(err, tt.wantErr))
+
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+func Test_quoteTable(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	is.Equal(quoteTable("USERS"), `"USERS"`)
+	is.Equal(quoteTable("Users"), `"Users"`)
+	is.Equal(quoteTable(`USERS"; DROP TABLE USERS; --`), `"USERS""; DROP TABLE USERS; --"`)
+	is.Equal(quoteColumn("userId"), `"userId"`)
+	is.Equal(quoteColumn(newColumn("userId")), `"USERID"`)
+}
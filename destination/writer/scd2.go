@@ -0,0 +1,158 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-db2/coltypes"
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// SCD2Columns are names of the columns written by the SCD2 write mode.
+type SCD2Columns struct {
+	// ValidFrom is a name of the column with the time the row became current.
+	ValidFrom string
+	// ValidTo is a name of the column with the time the row was closed, NULL for current rows.
+	ValidTo string
+	// Current is a name of the column which is 1 for current rows and 0 for closed rows.
+	Current string
+}
+
+// writeSCD2 closes the current row of the business key and, unless the record is a delete,
+// inserts the payload as the new current row, in a single transaction.
+// The structured record key is the business key, the time the record was read at is the validity boundary.
+func (w *Writer) writeSCD2(ctx context.Context, record opencdc.Record) error {
+	tableName, err := w.getTableName(record)
+	if err != nil {
+		return err
+	}
+
+	return w.write(ctx, record, tableName, func() error {
+		keys, err := w.structurizeData(record.Key)
+		if err != nil || len(keys) == 0 {
+			return fmt.Errorf("structured record key is the business key: %w", ErrEmptyKey)
+		}
+
+		keys, err = w.columnMapping.mapKey(keys)
+		if err != nil {
+			return fmt.Errorf("map key: %w", err)
+		}
+
+		validAt := recordTime(record)
+
+		var row opencdc.StructuredData
+		if record.Operation != opencdc.OperationDelete {
+			row, err = w.scd2Row(ctx, tableName, record, keys, validAt)
+			if err != nil {
+				return err
+			}
+		}
+
+		tableInfo, err := w.tables.get(ctx, tableName)
+		if err != nil {
+			return err
+		}
+
+		where, err := coltypes.ConvertStructureData(ctx, tableInfo, w.scd2Where(keys), w.convertOpts)
+		if err != nil {
+			return fmt.Errorf("convert key: %w", err)
+		}
+
+		closing, err := coltypes.ConvertStructureData(ctx, tableInfo, opencdc.StructuredData{
+			w.scd2Columns.ValidTo: validAt,
+			w.scd2Columns.Current: int64(0),
+		}, w.convertOpts)
+		if err != nil {
+			return fmt.Errorf("convert closing columns: %w", err)
+		}
+
+		return w.execSCD2(ctx, tableName, where, closing, row)
+	})
+}
+
+// scd2Row returns the new current row converted to the column types, creating or evolving the table if needed.
+func (w *Writer) scd2Row(
+	ctx context.Context,
+	tableName string,
+	record opencdc.Record,
+	keys opencdc.StructuredData,
+	validAt time.Time,
+) (opencdc.StructuredData, error) {
+	payload, err := w.structurizeData(record.Payload.After)
+	if err != nil {
+		return nil, fmt.Errorf("structurize payload: %w", err)
+	}
+
+	payload, err = w.columnMapping.mapPayload(payload)
+	if err != nil {
+		return nil, fmt.Errorf("map payload: %w", err)
+	}
+
+	if len(payload) == 0 {
+		return nil, ErrEmptyPayload
+	}
+
+	for column, value := range keys {
+		if _, ok := payloadField(payload, column); !ok {
+			payload[column] = value
+		}
+	}
+
+	payload[w.scd2Columns.ValidFrom] = validAt
+	payload[w.scd2Columns.Current] = int64(1)
+
+	// rows of the business key are not unique, so created tables don't have a primary key.
+	if err = w.prepareTable(ctx, tableName, record, nil, payload); err != nil {
+		return nil, fmt.Errorf("prepare table: %w", err)
+	}
+
+	return w.convertRow(ctx, tableName, record, payload)
+}
+
+// scd2Where returns the columns and values matching the current row of the business key.
+func (w *Writer) scd2Where(keys opencdc.StructuredData) opencdc.StructuredData {
+	where := make(opencdc.StructuredData, len(keys)+1)
+	for column, value := range keys {
+		where[column] = value
+	}
+
+	where[w.scd2Columns.Current] = int64(1)
+
+	return where
+}
+
+// execSCD2 closes the current row and inserts the new row, if it is not nil, in a transaction.
+func (w *Writer) execSCD2(
+	ctx context.Context,
+	tableName string,
+	where, closing, row opencdc.StructuredData,
+) error {
+	return w.inTransaction(ctx, func() error {
+		query, args := w.buildUpdateQuery(tableName, where, closing)
+
+		if _, err := w.conn().ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("exec close current row: %w", err)
+		}
+
+		if row == nil {
+			return nil
+		}
+
+		return w.execInsert(ctx, tableName, row)
+	})
+}
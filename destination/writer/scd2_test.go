@@ -0,0 +1,43 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestWriter_scd2(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	w := &Writer{
+		writeMode:   WriteModeSCD2,
+		typeMapping: DefaultTypeMapping,
+		scd2Columns: SCD2Columns{ValidFrom: "VALID_FROM", ValidTo: "VALID_TO", Current: "IS_CURRENT"},
+	}
+
+	is.Equal(w.scd2Where(opencdc.StructuredData{"ID": 1}), opencdc.StructuredData{"ID": 1, "IS_CURRENT": int64(1)})
+
+	columns := w.modeColumnTypes()
+	columns["ID"] = DefaultTypeMapping[KindLong]
+
+	query, err := buildCreateTableQuery("USERS_HISTORY", columns, nil)
+	is.NoErr(err)
+	is.Equal(query, `CREATE TABLE "USERS_HISTORY" ("ID" BIGINT, "IS_CURRENT" INTEGER, `+
+		`"VALID_FROM" TIMESTAMP(6), "VALID_TO" TIMESTAMP(6))`)
+}
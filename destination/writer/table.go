@@ -0,0 +1,418 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-db2/common"
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/conduitio/conduit-commons/schema"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	sdkschema "github.com/conduitio/conduit-connector-sdk/schema"
+	"github.com/hamba/avro/v2"
+)
+
+// Value kinds of the type mapping of created tables.
+const (
+	KindBoolean   = "boolean"
+	KindInt       = "int"
+	KindLong      = "long"
+	KindFloat     = "float"
+	KindDouble    = "double"
+	KindNumber    = "number"
+	KindString    = "string"
+	KindKeyString = "keyString"
+	KindBytes     = "bytes"
+	KindDate      = "date"
+	KindTime      = "time"
+	KindTimestamp = "timestamp"
+	KindJSON      = "json"
+)
+
+const (
+	queryCreateTable = `CREATE TABLE %s (%s)`
+
+	// maxDecimalPrecision is the maximum precision of DB2 DECIMAL columns.
+	maxDecimalPrecision = 31
+)
+
+// DefaultTypeMapping is a mapping of value kinds to DB2 column types of created tables.
+var DefaultTypeMapping = map[string]string{
+	KindBoolean:   "BOOLEAN",
+	KindInt:       "INTEGER",
+	KindLong:      "BIGINT",
+	KindFloat:     "REAL",
+	KindDouble:    "DOUBLE",
+	KindNumber:    "DECFLOAT(34)",
+	KindString:    "VARCHAR(4000)",
+	KindKeyString: "VARCHAR(255)",
+	KindBytes:     "BLOB(16M)",
+	KindDate:      "DATE",
+	KindTime:      "TIME",
+	KindTimestamp: "TIMESTAMP(6)",
+	KindJSON:      "CLOB(16M)",
+}
+
+// identifierRegexp matches ordinary DB2 identifiers, which rendered table names must be.
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,127}$`)
+
+// ensureTable creates the table if it doesn't exist.
+func (w *Writer) ensureTable(
+	ctx context.Context,
+	tableName string,
+	record opencdc.Record,
+	keys, payload opencdc.StructuredData,
+) error {
+	tableInfo, err := w.tables.get(ctx, tableName)
+	if err != nil {
+		return err
+	}
+
+	if len(tableInfo.ColumnTypes) > 0 {
+		return nil
+	}
+
+	if err = w.createTable(ctx, tableName, record, keys, payload); err != nil {
+		return err
+	}
+
+	w.tables.invalidate(tableName)
+
+	return nil
+}
+
+// createTable creates the table with columns of the record payload, the columns of the write mode
+// and the primary key of the record key.
+func (w *Writer) createTable(
+	ctx context.Context,
+	tableName string,
+	record opencdc.Record,
+	keys, payload opencdc.StructuredData,
+) error {
+	payloadTypes, err := w.schemaColumnTypes(ctx, record.Metadata.GetPayloadSchemaSubject,
+		record.Metadata.GetPayloadSchemaVersion)
+	if err != nil {
+		return fmt.Errorf("get payload schema column types: %w", err)
+	}
+
+	keyTypes, err := w.schemaColumnTypes(ctx, record.Metadata.GetKeySchemaSubject,
+		record.Metadata.GetKeySchemaVersion)
+	if err != nil {
+		return fmt.Errorf("get key schema column types: %w", err)
+	}
+
+	// schema fields are mapped to columns the same way as the payload fields.
+	payloadTypes, err = mapNames(w.columnMapping, payloadTypes)
+	if err != nil {
+		return fmt.Errorf("map payload schema fields: %w", err)
+	}
+
+	keyTypes, err = mapNames(w.columnMapping, keyTypes)
+	if err != nil {
+		return fmt.Errorf("map key schema fields: %w", err)
+	}
+
+	columns, primaryKeys, err := w.tableColumns(keys, payload, payloadTypes, keyTypes)
+	if err != nil {
+		return err
+	}
+
+	query, err := buildCreateTableQuery(tableName, columns, primaryKeys)
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.conn().ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("exec create table %q: %w", tableName, err)
+	}
+
+	sdk.Logger(ctx).Info().Str("table", tableName).Msg("created destination table")
+
+	return nil
+}
+
+// tableColumns returns the types of the columns created for the payload fields, the columns of the write mode
+// and the key fields, and the primary key columns. Fields are normalized to the names of the created columns
+// before they are compared, so different fields of the same column are reported as an error.
+func (w *Writer) tableColumns(
+	keys, payload opencdc.StructuredData,
+	payloadTypes, keyTypes map[string]string,
+) (map[string]string, []string, error) {
+	var (
+		columns   = make(map[string]string, len(payload)+len(keys))
+		fields    = make(map[string]string, len(payload))
+		keyFields = make(map[string]string, len(keys))
+	)
+
+	// add sets the type of the field's column. Key fields may be the payload fields of their columns.
+	add := func(fields map[string]string, field, dataType string) (string, error) {
+		column := newColumn(field)
+		if other, ok := fields[column]; ok && other != field {
+			return "", fmt.Errorf("fields %q and %q, column %q: %w", other, field, column, ErrDuplicateColumn)
+		}
+
+		fields[column] = field
+		columns[column] = dataType
+
+		return column, nil
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(payload)) {
+		dataType := payloadTypes[name]
+		if dataType == "" {
+			dataType = w.inferColumnType(payload[name])
+		}
+
+		if _, err := add(fields, name, dataType); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	modeTypes := w.modeColumnTypes()
+	for _, name := range slices.Sorted(maps.Keys(modeTypes)) {
+		if _, err := add(fields, name, modeTypes[name]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	primaryKeys := make([]string, 0, len(keys))
+	for _, name := range slices.Sorted(maps.Keys(keys)) {
+		dataType, ok := keyTypes[name]
+		if !ok {
+			dataType = payloadTypes[name]
+		}
+
+		if dataType == "" {
+			keyValue := keys[name]
+			if payloadValue, ok := payload[name]; ok && payloadValue != nil {
+				keyValue = payloadValue
+			}
+
+			dataType = w.inferColumnType(keyValue)
+			if dataType == w.typeMapping[KindString] {
+				dataType = w.typeMapping[KindKeyString]
+			}
+		}
+
+		column, err := add(keyFields, name, dataType)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		primaryKeys = append(primaryKeys, column)
+	}
+
+	return columns, primaryKeys, nil
+}
+
+// schemaColumnTypes returns column types of the Avro record schema referenced by the metadata,
+// or an empty map if the record has no schema.
+func (w *Writer) schemaColumnTypes(
+	ctx context.Context,
+	getSubject func() (string, error),
+	getVersion func() (int, error),
+) (map[string]string, error) {
+	subject, err := getSubject()
+	if errors.Is(err, opencdc.ErrMetadataFieldNotFound) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get schema subject: %w", err)
+	}
+
+	version, err := getVersion()
+	if err != nil {
+		return nil, fmt.Errorf("get schema version: %w", err)
+	}
+
+	sch, err := sdkschema.Get(ctx, subject, version)
+	if err != nil {
+		return nil, fmt.Errorf("get schema %s:%d: %w", subject, version, err)
+	}
+
+	if sch.Type != schema.TypeAvro {
+		return nil, fmt.Errorf("schema %s:%d: %w", subject, version, sdkschema.ErrUnsupportedType)
+	}
+
+	avroSchema, err := avro.Parse(string(sch.Bytes))
+	if err != nil {
+		return nil, fmt.Errorf("parse schema %s:%d: %w", subject, version, err)
+	}
+
+	recordSchema, ok := avroSchema.(*avro.RecordSchema)
+	if !ok {
+		return map[string]string{}, nil
+	}
+
+	columnTypes := make(map[string]string, len(recordSchema.Fields()))
+	for _, field := range recordSchema.Fields() {
+		columnTypes[field.Name()] = w.avroColumnType(field.Type())
+	}
+
+	return columnTypes, nil
+}
+
+// avroColumnType returns a DB2 column type for the Avro schema.
+func (w *Writer) avroColumnType(s avro.Schema) string {
+	switch s := s.(type) {
+	case *avro.UnionSchema:
+		// nullable fields are unions of null and another type.
+		if s.Nullable() && len(s.Types()) == 2 {
+			_, typ := s.Indices()
+
+			return w.avroColumnType(s.Types()[typ])
+		}
+
+		return w.typeMapping[KindJSON]
+
+	case *avro.PrimitiveSchema:
+		if logical := s.Logical(); logical != nil {
+			switch logical.Type() {
+			case avro.Decimal:
+				return decimalColumnType(logical, w.typeMapping)
+			case avro.Date:
+				return w.typeMapping[KindDate]
+			case avro.TimeMillis, avro.TimeMicros:
+				return w.typeMapping[KindTime]
+			case avro.TimestampMillis, avro.TimestampMicros, avro.LocalTimestampMillis, avro.LocalTimestampMicros:
+				return w.typeMapping[KindTimestamp]
+			}
+		}
+
+		switch s.Type() {
+		case avro.Boolean:
+			return w.typeMapping[KindBoolean]
+		case avro.Int:
+			return w.typeMapping[KindInt]
+		case avro.Long:
+			return w.typeMapping[KindLong]
+		case avro.Float:
+			return w.typeMapping[KindFloat]
+		case avro.Double:
+			return w.typeMapping[KindDouble]
+		case avro.Bytes:
+			return w.typeMapping[KindBytes]
+		default:
+			return w.typeMapping[KindString]
+		}
+
+	case *avro.FixedSchema:
+		if logical := s.Logical(); logical != nil && logical.Type() == avro.Decimal {
+			return decimalColumnType(logical, w.typeMapping)
+		}
+
+		return w.typeMapping[KindBytes]
+
+	case *avro.EnumSchema:
+		return w.typeMapping[KindString]
+
+	default:
+		return w.typeMapping[KindJSON]
+	}
+}
+
+// decimalColumnType returns a DECIMAL column type with the precision and scale of the Avro decimal,
+// or the number type if it doesn't fit DECIMAL.
+func decimalColumnType(logical avro.LogicalSchema, typeMapping map[string]string) string {
+	decimal, ok := logical.(*avro.DecimalLogicalSchema)
+	if !ok || decimal.Precision() > maxDecimalPrecision {
+		return typeMapping[KindNumber]
+	}
+
+	return fmt.Sprintf("DECIMAL(%d,%d)", decimal.Precision(), decimal.Scale())
+}
+
+// inferColumnType returns a DB2 column type for the payload value.
+func (w *Writer) inferColumnType(value any) string {
+	switch v := value.(type) {
+	case bool:
+		return w.typeMapping[KindBoolean]
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return w.typeMapping[KindLong]
+		}
+
+		return w.typeMapping[KindNumber]
+	case int8, int16, int32, uint8, uint16:
+		return w.typeMapping[KindInt]
+	case int, int64, uint, uint32, uint64:
+		return w.typeMapping[KindLong]
+	case float32:
+		return w.typeMapping[KindFloat]
+	case float64:
+		return w.typeMapping[KindDouble]
+	case *big.Rat, *big.Int:
+		return w.typeMapping[KindNumber]
+	case []byte:
+		return w.typeMapping[KindBytes]
+	case time.Time:
+		return w.typeMapping[KindTimestamp]
+	case map[string]any, []any, opencdc.StructuredData:
+		return w.typeMapping[KindJSON]
+	default:
+		return w.typeMapping[KindString]
+	}
+}
+
+// buildCreateTableQuery generates a CREATE TABLE statement with the provided columns and primary keys.
+func buildCreateTableQuery(table string, columns map[string]string, primaryKeys []string) (string, error) {
+	if err := common.ValidateIdentifier(table); err != nil {
+		return "", fmt.Errorf("table: %w", err)
+	}
+
+	if len(columns) == 0 {
+		return "", ErrEmptyPayload
+	}
+
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		if err := common.ValidateIdentifier(name); err != nil {
+			return "", fmt.Errorf("column: %w", err)
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	sort.Strings(primaryKeys)
+
+	definitions := make([]string, 0, len(names)+1)
+	for _, name := range names {
+		definition := fmt.Sprintf("%s %s", quoteColumn(name), columns[name])
+		if slices.Contains(primaryKeys, name) {
+			definition += " NOT NULL"
+		}
+
+		definitions = append(definitions, definition)
+	}
+
+	if len(primaryKeys) > 0 {
+		definitions = append(definitions,
+			fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(quoteColumns(primaryKeys), ",")))
+	}
+
+	return fmt.Sprintf(queryCreateTable, quoteTable(table), strings.Join(definitions, ", ")), nil
+}
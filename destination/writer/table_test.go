@@ -0,0 +1,195 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/hamba/avro/v2"
+	"github.com/matryer/is"
+)
+
+func TestBuildCreateTableQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		table       string
+		columns     map[string]string
+		primaryKeys []string
+		want        string
+		wantErr     error
+	}{
+		{
+			name:        "with primary key",
+			table:       "USERS",
+			columns:     map[string]string{"ID": "BIGINT", "NAME": "VARCHAR(4000)"},
+			primaryKeys: []string{"ID"},
+			want:        `CREATE TABLE "USERS" ("ID" BIGINT NOT NULL, "NAME" VARCHAR(4000), PRIMARY KEY ("ID"))`,
+		},
+		{
+			name:    "without primary key",
+			table:   "EVENTS",
+			columns: map[string]string{"PAYLOAD": "CLOB(16M)"},
+			want:    `CREATE TABLE "EVENTS" ("PAYLOAD" CLOB(16M))`,
+		},
+		{
+			name:    "delimited names",
+			table:   `users"; DROP TABLE x; --`,
+			columns: map[string]string{"first-name": "VARCHAR(4000)"},
+			want:    `CREATE TABLE "users""; DROP TABLE x; --" ("first-name" VARCHAR(4000))`,
+		},
+		{
+			name:    "invalid table name",
+			table:   "",
+			columns: map[string]string{"ID": "BIGINT"},
+			wantErr: ErrInvalidIdentifier,
+		},
+		{
+			name:    "invalid column name",
+			table:   "USERS",
+			columns: map[string]string{"first\x00name": "VARCHAR(4000)"},
+			wantErr: ErrInvalidIdentifier,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			got, err := buildCreateTableQuery(tt.table, tt.columns, tt.primaryKeys)
+			if tt.wantErr != nil {
+				is.True(errors.Is(err, tt.wantErr))
+
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+func TestWriter_tableColumns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		keys            opencdc.StructuredData
+		payload         opencdc.StructuredData
+		payloadTypes    map[string]string
+		wantColumns     map[string]string
+		wantPrimaryKeys []string
+		wantErr         error
+	}{
+		{
+			name:            "normalized names",
+			keys:            opencdc.StructuredData{"id": json.Number("1")},
+			payload:         opencdc.StructuredData{"id": json.Number("1"), "userName": "alex"},
+			payloadTypes:    map[string]string{"userName": "VARCHAR(100)"},
+			wantColumns:     map[string]string{"ID": "BIGINT", "USERNAME": "VARCHAR(100)"},
+			wantPrimaryKeys: []string{"ID"},
+		},
+		{
+			name:    "fields of the same column",
+			payload: opencdc.StructuredData{"userName": "alex", "USERNAME": "alex"},
+			wantErr: ErrDuplicateColumn,
+		},
+		{
+			name:            "key field of a payload column",
+			keys:            opencdc.StructuredData{"ID": json.Number("1")},
+			payload:         opencdc.StructuredData{"id": json.Number("1")},
+			wantColumns:     map[string]string{"ID": "BIGINT"},
+			wantPrimaryKeys: []string{"ID"},
+		},
+		{
+			name:    "key fields of the same column",
+			keys:    opencdc.StructuredData{"ID": json.Number("1"), "id": json.Number("1")},
+			wantErr: ErrDuplicateColumn,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			w := &Writer{typeMapping: DefaultTypeMapping}
+
+			columns, primaryKeys, err := w.tableColumns(tt.keys, tt.payload, tt.payloadTypes, nil)
+			if tt.wantErr != nil {
+				is.True(errors.Is(err, tt.wantErr))
+
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(columns, tt.wantColumns)
+			is.Equal(primaryKeys, tt.wantPrimaryKeys)
+		})
+	}
+}
+
+func TestWriter_inferColumnType(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	w := &Writer{typeMapping: DefaultTypeMapping}
+
+	is.Equal(w.inferColumnType(true), "BOOLEAN")
+	is.Equal(w.inferColumnType(json.Number("42")), "BIGINT")
+	is.Equal(w.inferColumnType(json.Number("4.2")), "DECFLOAT(34)")
+	is.Equal(w.inferColumnType("name"), "VARCHAR(4000)")
+	is.Equal(w.inferColumnType(nil), "VARCHAR(4000)")
+	is.Equal(w.inferColumnType(map[string]any{"a": 1}), "CLOB(16M)")
+	is.Equal(w.inferColumnType([]byte("a")), "BLOB(16M)")
+}
+
+func TestWriter_avroColumnType(t *testing.T) {
+	t.Parallel()
+
+	w := &Writer{typeMapping: DefaultTypeMapping}
+
+	tests := []struct {
+		schema string
+		want   string
+	}{
+		{schema: `"int"`, want: "INTEGER"},
+		{schema: `"long"`, want: "BIGINT"},
+		{schema: `["null", "string"]`, want: "VARCHAR(4000)"},
+		{schema: `{"type": "int", "logicalType": "date"}`, want: "DATE"},
+		{schema: `{"type": "long", "logicalType": "timestamp-micros"}`, want: "TIMESTAMP(6)"},
+		{schema: `{"type": "bytes", "logicalType": "decimal", "precision": 10, "scale": 2}`, want: "DECIMAL(10,2)"},
+		{schema: `{"type": "bytes", "logicalType": "decimal", "precision": 38, "scale": 2}`, want: "DECFLOAT(34)"},
+		{schema: `{"type": "array", "items": "int"}`, want: "CLOB(16M)"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.schema, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			s, err := avro.Parse(tt.schema)
+			is.NoErr(err)
+			is.Equal(w.avroColumnType(s), tt.want)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/huandu/go-sqlbuilder"
+)
+
+// versionedUpdate updates the row only if the version of the payload is greater than the stored version,
+// or the stored version is NULL. Updates of rows which don't exist are inserted, the way the bulk MERGE applies
+// them, and other updates which don't change any row are counted and logged as stale.
+func (w *Writer) versionedUpdate(
+	ctx context.Context,
+	tableName string,
+	keys, payload opencdc.StructuredData,
+) error {
+	query, args, err := w.buildVersionedUpdateQuery(tableName, keys, payload)
+	if err != nil {
+		return err
+	}
+
+	result, err := w.conn().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("exec update: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get affected rows: %w", err)
+	}
+
+	if affected > 0 {
+		return nil
+	}
+
+	exists, err := w.rowExists(ctx, tableName, keys)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		row := make(opencdc.StructuredData, len(payload)+len(keys))
+		for column, value := range keys {
+			row[column] = value
+		}
+
+		for column, value := range payload {
+			row[column] = value
+		}
+
+		return w.execInsert(ctx, tableName, row)
+	}
+
+	w.staleRecords++
+
+	sdk.Logger(ctx).Warn().
+		Str("table", tableName).
+		Any("keys", keys).
+		Int("staleRecords", w.staleRecords).
+		Msg("rejected a stale update, the stored version is not older")
+
+	return nil
+}
+
+// rowExists returns true if the table has a row with the keys.
+func (w *Writer) rowExists(ctx context.Context, tableName string, keys opencdc.StructuredData) (bool, error) {
+	query, args := buildRowExistsQuery(tableName, keys)
+
+	rows, err := w.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("query row exists: %w", err)
+	}
+	defer rows.Close()
+
+	exists := rows.Next()
+	if err = rows.Err(); err != nil {
+		return false, fmt.Errorf("iterate row exists: %w", err)
+	}
+
+	return exists, nil
+}
+
+// buildRowExistsQuery generates an SQL SELECT statement which returns a row if the table has a row with the keys.
+func buildRowExistsQuery(table string, keys opencdc.StructuredData) (string, []any) {
+	sb := sqlbuilder.NewSelectBuilder()
+
+	sb.Select("1").From(quoteTable(table))
+
+	for key, val := range keys {
+		sb.Where(sb.Equal(quoteColumn(key), val))
+	}
+
+	query, args := sb.Build()
+
+	return query + " FETCH FIRST 1 ROW ONLY", args
+}
+
+// buildVersionedUpdateQuery generates an SQL UPDATE statement of the row with the keys,
+// which is only applied if the stored version is lower than the version of the payload, or NULL.
+func (w *Writer) buildVersionedUpdateQuery(
+	table string,
+	keys, payload opencdc.StructuredData,
+) (string, []any, error) {
+	column, ok := payloadField(payload, w.versionColumn)
+	if !ok {
+		return "", nil, fmt.Errorf("version column %q: %w", w.versionColumn, ErrMissingVersion)
+	}
+
+	up := w.newUpdateBuilder(table, keys, payload)
+	up.Where(up.Or(
+		up.LessThan(quoteColumn(column), payload[column]),
+		up.IsNull(quoteColumn(column)),
+	))
+
+	query, args := up.Build()
+
+	return query, args, nil
+}
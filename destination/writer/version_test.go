@@ -0,0 +1,53 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestWriter_buildVersionedUpdateQuery(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	w := &Writer{versionColumn: "VERSION"}
+
+	query, args, err := w.buildVersionedUpdateQuery("USERS",
+		opencdc.StructuredData{"ID": int64(1)},
+		opencdc.StructuredData{"VERSION": int64(7)},
+	)
+	is.NoErr(err)
+	is.Equal(query, `UPDATE "USERS" SET "VERSION" = ? WHERE "ID" = ? AND ("VERSION" < ? OR "VERSION" IS NULL)`)
+	is.Equal(args, []any{int64(7), int64(1), int64(7)})
+
+	_, _, err = w.buildVersionedUpdateQuery("USERS",
+		opencdc.StructuredData{"ID": int64(1)},
+		opencdc.StructuredData{"NAME": "alex"},
+	)
+	is.True(errors.Is(err, ErrMissingVersion))
+}
+
+func Test_buildRowExistsQuery(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	query, args := buildRowExistsQuery("USERS", opencdc.StructuredData{"ID": int64(1)})
+	is.Equal(query, `SELECT 1 FROM "USERS" WHERE "ID" = ? FETCH FIRST 1 ROW ONLY`)
+	is.Equal(args, []any{int64(1)})
+}
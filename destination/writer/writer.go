@@ -15,32 +15,131 @@
 package writer
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
 
 	"github.com/conduitio-labs/conduit-connector-db2/coltypes"
+	"github.com/conduitio-labs/conduit-connector-db2/common"
 	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
 	"github.com/huandu/go-sqlbuilder"
 )
 
 const (
 	// metadata related.
 	metadataTable = "db2.table"
+	// metadataSnapshotCompleted marks the last record of a snapshot of the DB2 source.
+	metadataSnapshotCompleted = "db2.snapshot.completed"
 )
 
+// conn executes statements and queries in the database or in a transaction.
+type conn interface {
+	coltypes.Querier
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// connQuerier queries the connection of the writer.
+type connQuerier struct {
+	w *Writer
+}
+
+// QueryContext executes the query in the transaction of the writer, if there is one.
+func (q connQuerier) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return q.w.conn().QueryContext(ctx, query, args...) //nolint:wrapcheck,sqlclosecheck // rows are closed by the caller
+}
+
 // Writer implements a writer logic for db2 destination.
 type Writer struct {
-	db          *sql.DB
-	table       string
-	columnTypes map[string]string
+	db    *sql.DB
+	table string
+	// tx is a transaction of the record being written, if the record is written in a transaction.
+	tx *sql.Tx
+	// committed are functions which run after the transaction commits.
+	committed []func()
+	// tableTemplate renders table names from the records, if the table is a template.
+	tableTemplate *template.Template
+	// tables cached information about columns of the destination tables.
+	tables *tableInfoCache
+	// convertOpts options for converting payload values.
+	convertOpts coltypes.ConvertOptions
+	// autoCreateTable creates missing tables from the records.
+	autoCreateTable bool
+	// typeMapping value kinds with DB2 column types of created tables.
+	typeMapping map[string]string
+	// schemaEvolution way of handling payload fields which are not columns of the table.
+	schemaEvolution SchemaEvolution
+	// widenVarchar increases lengths of VARCHAR columns which are too short for the payload values.
+	widenVarchar bool
+	// columnMapping maps payload and key fields to columns.
+	columnMapping ColumnMapping
+	// writeMode is a way of writing records to the table.
+	writeMode WriteMode
+	// softDeleteOpts options of the soft-delete write mode.
+	softDeleteOpts SoftDeleteOptions
+	// appendColumns columns of the append-only write mode.
+	appendColumns AppendColumns
+	// scd2Columns columns of the SCD2 write mode.
+	scd2Columns SCD2Columns
+	// checkpointOpts options of storing positions of the applied records.
+	checkpointOpts Checkpoints
+	// checkpoints - pipeline with positions of the last applied records by table.
+	checkpoints map[string]map[string]opencdc.Position
+	// reloads - pipeline with the tables whose reloads are in progress.
+	reloads map[string]map[string]bool
+	// versionColumn a column which value must increase for updates to be applied.
+	versionColumn string
+	// staleRecords a number of updates rejected because their version is not greater than the stored one.
+	staleRecords int
+	// bulk options of writing batches through a staging table.
+	bulk BulkOptions
+	// reloadMode is a way of removing stale rows when a new snapshot starts.
+	reloadMode ReloadMode
+	// reloading - name of the reloaded table with the table its snapshot is written to.
+	reloading map[string]string
+	// reloadsChecked - names of the tables whose interrupted reloads were checked.
+	reloadsChecked map[string]bool
 }
 
 // Params is an incoming params for the NewWriter function.
 type Params struct {
-	DB    *sql.DB
-	Table string
+	DB             *sql.DB
+	Table          string
+	Location       *time.Location
+	BinaryEncoding coltypes.BinaryEncoding
+	LOBMaxBytes    int
+	// AutoCreateTable creates missing tables from the records.
+	AutoCreateTable bool
+	// TypeMapping overrides column types of DefaultTypeMapping.
+	TypeMapping map[string]string
+	// SchemaEvolution is a way of handling payload fields which are not columns of the table.
+	SchemaEvolution SchemaEvolution
+	// WidenVarchar increases lengths of VARCHAR columns which are too short for the payload values.
+	WidenVarchar bool
+	// ColumnMapping maps payload and key fields to columns.
+	ColumnMapping ColumnMapping
+	// WriteMode is a way of writing records to the table, WriteModeStandard by default.
+	WriteMode WriteMode
+	// SoftDelete options of the soft-delete write mode.
+	SoftDelete SoftDeleteOptions
+	// AppendColumns columns of the append-only write mode.
+	AppendColumns AppendColumns
+	// SCD2Columns columns of the SCD2 write mode.
+	SCD2Columns SCD2Columns
+	// Checkpoints options of storing positions of the applied records.
+	Checkpoints Checkpoints
+	// VersionColumn is a column which value must increase for updates to be applied.
+	VersionColumn string
+	// Bulk options of writing batches through a staging table.
+	Bulk BulkOptions
+	// ReloadMode is a way of removing stale rows when a new snapshot starts.
+	ReloadMode ReloadMode
 }
 
 // NewWriter creates new instance of the Writer.
@@ -48,108 +147,317 @@ func NewWriter(ctx context.Context, params Params) (*Writer, error) {
 	writer := &Writer{
 		db:    params.DB,
 		table: params.Table,
+		convertOpts: coltypes.ConvertOptions{
+			Location:       params.Location,
+			BinaryEncoding: params.BinaryEncoding,
+			LOBMaxBytes:    params.LOBMaxBytes,
+		},
+		autoCreateTable: params.AutoCreateTable,
+		typeMapping:     make(map[string]string, len(DefaultTypeMapping)),
+		schemaEvolution: params.SchemaEvolution,
+		widenVarchar:    params.WidenVarchar,
+		columnMapping:   params.ColumnMapping,
+		writeMode:       params.WriteMode,
+		softDeleteOpts:  params.SoftDelete,
+		appendColumns:   params.AppendColumns,
+		scd2Columns:     params.SCD2Columns,
+		checkpointOpts:  params.Checkpoints,
+		checkpoints:     make(map[string]map[string]opencdc.Position),
+		reloads:         make(map[string]map[string]bool),
+		versionColumn:   params.VersionColumn,
+		bulk:            params.Bulk,
+		reloadMode:      params.ReloadMode,
+		reloading:       make(map[string]string),
+		reloadsChecked:  make(map[string]bool),
 	}
 
-	tableInfo, err := coltypes.GetTableInfo(ctx, writer.db, writer.table)
-	if err != nil {
-		return nil, fmt.Errorf("get column types: %w", err)
+	writer.tables = newTableInfoCache(connQuerier{w: writer})
+
+	for kind, dataType := range DefaultTypeMapping {
+		writer.typeMapping[kind] = dataType
+	}
+
+	for kind, dataType := range params.TypeMapping {
+		writer.typeMapping[kind] = dataType
+	}
+
+	if writer.checkpointOpts.Table != "" {
+		if err := writer.ensureCheckpointTable(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if IsTableTemplate(params.Table) {
+		tableTemplate, err := ParseTableTemplate(params.Table)
+		if err != nil {
+			return nil, err
+		}
+
+		writer.tableTemplate = tableTemplate
+
+		return writer, nil
+	}
+
+	if _, err := writer.tables.get(ctx, writer.table); err != nil {
+		return nil, err
 	}
-	writer.columnTypes = tableInfo.ColumnTypes
 
 	return writer, nil
 }
 
-// Close closes the underlying db connection.
-func (w *Writer) Close(context.Context) error {
-	return w.db.Close()
+// Close finishes the work of the writer. The db connection pool is owned by the destination,
+// which closes it on teardown.
+func (w *Writer) Close(ctx context.Context) error {
+	if w.staleRecords > 0 {
+		sdk.Logger(ctx).Info().Int("count", w.staleRecords).Msg("rejected stale updates")
+	}
+
+	return nil
 }
 
 // Delete deletes records by a key. First it looks in the opencdc.Record.Key,
-// if it doesn't find a key there it will use the default configured value for a key.
+// if it doesn't find a key there it will use the primary keys of the table from the payload before the change.
 func (w *Writer) Delete(ctx context.Context, record opencdc.Record) error {
-	tableName := w.getTableName(record.Metadata)
+	switch w.writeMode {
+	case WriteModeSoftDelete:
+		return w.softDelete(ctx, record)
+	case WriteModeAppendOnly:
+		return w.appendRecord(ctx, record)
+	case WriteModeSCD2:
+		return w.writeSCD2(ctx, record)
+	}
 
-	keys, err := w.structurizeData(record.Key)
+	tableName, err := w.resolveTable(ctx, record)
 	if err != nil {
-		return fmt.Errorf("structurize key: %w", err)
+		return err
 	}
 
-	if len(keys) == 0 {
-		return ErrEmptyKey
+	return w.write(ctx, record, tableName, func() error {
+		_, keys, err := w.deleteKeys(ctx, tableName, record)
+		if err != nil {
+			return err
+		}
+
+		query, args := w.buildDeleteQuery(tableName, keys)
+
+		_, err = w.conn().ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("exec delete: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// deleteKeys returns information about the table and the keys of the deleted row.
+func (w *Writer) deleteKeys(
+	ctx context.Context,
+	tableName string,
+	record opencdc.Record,
+) (coltypes.TableInfo, opencdc.StructuredData, error) {
+	tableInfo, err := w.tables.get(ctx, tableName)
+	if err != nil {
+		return coltypes.TableInfo{}, nil, err
+	}
+
+	before, err := w.structurizeData(record.Payload.Before)
+	if err != nil {
+		return coltypes.TableInfo{}, nil, fmt.Errorf("structurize payload: %w", err)
 	}
 
-	query, args := w.buildDeleteQuery(tableName, keys)
+	// only the primary keys are taken from the payload, so it is mapped without the include and exclude lists.
+	before, err = w.columnMapping.mapKey(before)
+	if err != nil {
+		return coltypes.TableInfo{}, nil, fmt.Errorf("map payload: %w", err)
+	}
 
-	_, err = w.db.ExecContext(ctx, query, args...)
+	keys, err := w.resolveKeys(ctx, record, tableInfo, before)
 	if err != nil {
-		return fmt.Errorf("exec delete: %w", err)
+		return coltypes.TableInfo{}, nil, err
 	}
 
-	return nil
+	return tableInfo, keys, nil
 }
 
 // Update updates records by a key.
 func (w *Writer) Update(ctx context.Context, record opencdc.Record) error {
-	tableName := w.getTableName(record.Metadata)
+	switch w.writeMode {
+	case WriteModeAppendOnly:
+		return w.appendRecord(ctx, record)
+	case WriteModeSCD2:
+		return w.writeSCD2(ctx, record)
+	}
 
-	payload, err := w.structurizeData(record.Payload.After)
+	tableName, err := w.resolveTable(ctx, record)
 	if err != nil {
-		return fmt.Errorf("structurize payload: %w", err)
+		return err
 	}
 
-	// if payload is empty return empty payload error
-	if payload == nil {
-		return ErrEmptyPayload
-	}
+	return w.write(ctx, record, tableName, func() error {
+		payload, err := w.structurizeData(record.Payload.After)
+		if err != nil {
+			return fmt.Errorf("structurize payload: %w", err)
+		}
+
+		payload, err = w.columnMapping.mapPayload(payload)
+		if err != nil {
+			return fmt.Errorf("map payload: %w", err)
+		}
+
+		// if payload is empty return empty payload error
+		if len(payload) == 0 {
+			return ErrEmptyPayload
+		}
+
+		// scalar keys are resolved with the primary key of the table.
+		rawKeys, _ := w.structurizeData(record.Key)
+
+		rawKeys, err = w.columnMapping.mapKey(rawKeys)
+		if err != nil {
+			return fmt.Errorf("map key: %w", err)
+		}
+
+		if err = w.prepareTable(ctx, tableName, record, rawKeys, payload); err != nil {
+			return fmt.Errorf("prepare table: %w", err)
+		}
+
+		payload, err = w.evolveTable(ctx, tableName, record, payload)
+		if err != nil {
+			return fmt.Errorf("evolve table: %w", err)
+		}
+
+		tableInfo, err := w.tables.get(ctx, tableName)
+		if err != nil {
+			return err
+		}
+
+		keys, err := w.resolveKeys(ctx, record, tableInfo, payload)
+		if err != nil {
+			return err
+		}
+
+		payload, err = coltypes.ConvertStructureData(ctx, tableInfo, payload, w.convertOpts)
+		if err != nil {
+			return fmt.Errorf("convert structure data: %w", err)
+		}
+
+		if w.versionColumn != "" {
+			return w.versionedUpdate(ctx, tableName, keys, payload)
+		}
+
+		query, args := w.buildUpdateQuery(tableName, keys, payload)
+
+		_, err = w.conn().ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("exec update: %w", err)
+		}
+
+		return nil
+	})
+}
 
-	payload, err = coltypes.ConvertStructureData(ctx, w.columnTypes, payload)
-	if err != nil {
-		return fmt.Errorf("convert structure data: %w", err)
+// Insert row to sql server db.
+func (w *Writer) Insert(ctx context.Context, record opencdc.Record) error {
+	switch w.writeMode {
+	case WriteModeAppendOnly:
+		return w.appendRecord(ctx, record)
+	case WriteModeSCD2:
+		return w.writeSCD2(ctx, record)
 	}
 
-	keys, err := w.structurizeData(record.Key)
+	tableName, err := w.resolveTable(ctx, record)
 	if err != nil {
-		return fmt.Errorf("structurize key: %w", err)
+		return err
 	}
 
-	if len(keys) == 0 {
-		return ErrEmptyKey
+	err = w.write(ctx, record, tableName, func() error {
+		payload, err := w.structurizeData(record.Payload.After)
+		if err != nil {
+			return fmt.Errorf("structurize payload: %w", err)
+		}
+
+		payload, err = w.columnMapping.mapPayload(payload)
+		if err != nil {
+			return fmt.Errorf("map payload: %w", err)
+		}
+
+		// if payload is empty return empty payload error
+		if len(payload) == 0 {
+			return ErrEmptyPayload
+		}
+
+		if w.autoCreateTable {
+			keys, er := w.structurizeData(record.Key)
+			if er != nil {
+				return fmt.Errorf("structurize key: %w", er)
+			}
+
+			keys, er = w.columnMapping.mapKey(keys)
+			if er != nil {
+				return fmt.Errorf("map key: %w", er)
+			}
+
+			if err = w.prepareTable(ctx, tableName, record, keys, payload); err != nil {
+				return fmt.Errorf("prepare table: %w", err)
+			}
+		}
+
+		return w.insertRow(ctx, tableName, record, payload)
+	})
+	if err != nil {
+		return err
 	}
 
-	query, args := w.buildUpdateQuery(tableName, keys, payload)
+	return w.finishCompletedSnapshot(ctx, record)
+}
 
-	_, err = w.db.ExecContext(ctx, query, args...)
+// insertRow evolves the table for the payload, converts the payload to the column types and inserts it.
+func (w *Writer) insertRow(
+	ctx context.Context,
+	tableName string,
+	record opencdc.Record,
+	payload opencdc.StructuredData,
+) error {
+	payload, err := w.convertRow(ctx, tableName, record, payload)
 	if err != nil {
-		return fmt.Errorf("exec update: %w", err)
+		return err
 	}
 
-	return nil
+	return w.execInsert(ctx, tableName, payload)
 }
 
-// Insert row to sql server db.
-func (w *Writer) Insert(ctx context.Context, record opencdc.Record) error {
-	tableName := w.getTableName(record.Metadata)
-
-	payload, err := w.structurizeData(record.Payload.After)
+// convertRow evolves the table for the payload and converts the payload to the column types.
+func (w *Writer) convertRow(
+	ctx context.Context,
+	tableName string,
+	record opencdc.Record,
+	payload opencdc.StructuredData,
+) (opencdc.StructuredData, error) {
+	payload, err := w.evolveTable(ctx, tableName, record, payload)
 	if err != nil {
-		return fmt.Errorf("structurize payload: %w", err)
+		return nil, fmt.Errorf("evolve table: %w", err)
 	}
 
-	// if payload is empty return empty payload error
-	if payload == nil {
-		return ErrEmptyPayload
+	tableInfo, err := w.tables.get(ctx, tableName)
+	if err != nil {
+		return nil, err
 	}
 
-	payload, err = coltypes.ConvertStructureData(ctx, w.columnTypes, payload)
+	payload, err = coltypes.ConvertStructureData(ctx, tableInfo, payload, w.convertOpts)
 	if err != nil {
-		return fmt.Errorf("convert structure data: %w", err)
+		return nil, fmt.Errorf("convert structure data: %w", err)
 	}
 
+	return payload, nil
+}
+
+// execInsert inserts the converted payload.
+func (w *Writer) execInsert(ctx context.Context, tableName string, payload opencdc.StructuredData) error {
 	columns, values := w.extractColumnsAndValues(payload)
 
 	query, args := w.buildInsertQuery(tableName, columns, values)
 
-	_, err = w.db.ExecContext(ctx, query, args...)
+	_, err := w.conn().ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("exec upsert: %w", err)
 	}
@@ -157,15 +465,197 @@ func (w *Writer) Insert(ctx context.Context, record opencdc.Record) error {
 	return nil
 }
 
-// getTableName returns either the records metadata value for table
-// or the default configured value for table.
-func (w *Writer) getTableName(metadata map[string]string) string {
-	tableName, ok := metadata[metadataTable]
-	if !ok {
-		return w.table
+// conn returns the transaction of the record being written, or the database.
+func (w *Writer) conn() conn {
+	if w.tx != nil {
+		return w.tx
+	}
+
+	return w.db
+}
+
+// inTransaction runs the function in the transaction of the record being written,
+// or in a new transaction if there is none.
+func (w *Writer) inTransaction(ctx context.Context, fn func() error) error {
+	if w.tx != nil {
+		return fn()
+	}
+
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer tx.Rollback() // nolint:errcheck,nolintlint
+
+	w.tx = tx
+	committed := false
+	defer func() {
+		w.tx = nil
+		w.committed = nil
+
+		// tables created or altered in the transaction are rolled back with it,
+		// so the cached information about them is stale.
+		if !committed {
+			w.tables.reset()
+		}
+	}()
+
+	if err = fn(); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	committed = true
+
+	for _, committed := range w.committed {
+		committed()
+	}
+
+	return nil
+}
+
+// afterCommit runs the function after the transaction of the record being written commits,
+// or immediately if there is no transaction, so in-memory state doesn't get ahead of the database.
+func (w *Writer) afterCommit(fn func()) {
+	if w.tx == nil {
+		fn()
+
+		return
+	}
+
+	w.committed = append(w.committed, fn)
+}
+
+// withRefresh runs the write and, if it fails because a column or the table is unknown to the database,
+// e.g. after the table was altered or replaced by a reload, refreshes the cached information about the table
+// and runs the write once again.
+func (w *Writer) withRefresh(ctx context.Context, tableName string, write func() error) error {
+	err := write()
+	if err == nil {
+		return nil
+	}
+
+	switch common.SQLState(err) {
+	case common.SQLStateUndefinedColumn, common.SQLStateUndefinedTable:
+	default:
+		return err
+	}
+
+	sdk.Logger(ctx).Debug().Str("table", tableName).Err(err).Msg("undefined column or table, refreshing table metadata")
+
+	w.tables.invalidate(tableName)
+
+	return write()
+}
+
+// prepareTable creates the table if it is missing and the automatic creation of tables is enabled.
+func (w *Writer) prepareTable(
+	ctx context.Context,
+	tableName string,
+	record opencdc.Record,
+	keys, payload opencdc.StructuredData,
+) error {
+	if !w.autoCreateTable {
+		return nil
+	}
+
+	return w.ensureTable(ctx, tableName, record, keys, payload)
+}
+
+// resolveKeys returns the key columns with their values converted to the column types.
+// Keys are taken from the structured record key, a scalar record key is used as a value of the single
+// primary key of the table, and if the record has no key, values of the primary keys are taken from the payload.
+func (w *Writer) resolveKeys(
+	ctx context.Context,
+	record opencdc.Record,
+	tableInfo coltypes.TableInfo,
+	payload opencdc.StructuredData,
+) (opencdc.StructuredData, error) {
+	keys, err := w.structurizeData(record.Key)
+	if err != nil {
+		if len(tableInfo.PrimaryKeys) != 1 {
+			return nil, fmt.Errorf("structurize key: %w", err)
+		}
+
+		keys = opencdc.StructuredData{tableInfo.PrimaryKeys[0]: scalarKey(record.Key)}
+	} else {
+		keys, err = w.columnMapping.mapKey(keys)
+		if err != nil {
+			return nil, fmt.Errorf("map key: %w", err)
+		}
+	}
+
+	if len(keys) == 0 && len(payload) > 0 && len(tableInfo.PrimaryKeys) > 0 {
+		keys = make(opencdc.StructuredData, len(tableInfo.PrimaryKeys))
+
+		for _, primaryKey := range tableInfo.PrimaryKeys {
+			field, ok := payloadField(payload, primaryKey)
+			if !ok {
+				return nil, fmt.Errorf("primary key %q: %w", primaryKey, ErrEmptyKey)
+			}
+
+			keys[field] = payload[field]
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, ErrEmptyKey
+	}
+
+	keys, err = coltypes.ConvertStructureData(ctx, tableInfo, keys, w.convertOpts)
+	if err != nil {
+		return nil, fmt.Errorf("convert key: %w", err)
+	}
+
+	return keys, nil
+}
+
+// payloadField returns the name of the payload field of the column, the field with exactly the same name,
+// or else a field which matches it case-insensitively.
+func payloadField(payload opencdc.StructuredData, column string) (string, bool) {
+	if _, ok := payload[column]; ok {
+		return column, true
 	}
 
-	return tableName
+	for field := range payload {
+		if strings.EqualFold(field, column) {
+			return field, true
+		}
+	}
+
+	return "", false
+}
+
+// columnOf returns the name in the list which is exactly the column, or else matches it case-insensitively.
+func columnOf(names []string, column string) (string, bool) {
+	if slices.Contains(names, column) {
+		return column, true
+	}
+
+	for _, name := range names {
+		if strings.EqualFold(name, column) {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// scalarKey returns a value of the raw record key, which is a JSON scalar or a plain string.
+func scalarKey(key opencdc.Data) any {
+	decoder := json.NewDecoder(bytes.NewReader(key.Bytes()))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err == nil {
+		return value
+	}
+
+	return string(key.Bytes())
 }
 
 // buildDeleteQuery generates an SQL DELETE statement query,
@@ -173,11 +663,11 @@ func (w *Writer) getTableName(metadata map[string]string) string {
 func (w *Writer) buildDeleteQuery(table string, keys map[string]any) (string, []any) {
 	db := sqlbuilder.NewDeleteBuilder()
 
-	db.DeleteFrom(table)
+	db.DeleteFrom(quoteTable(table))
 
 	for key, val := range keys {
 		db.Where(
-			db.Equal(key, val),
+			db.Equal(quoteColumn(key), val),
 		)
 	}
 
@@ -187,31 +677,36 @@ func (w *Writer) buildDeleteQuery(table string, keys map[string]any) (string, []
 }
 
 func (w *Writer) buildUpdateQuery(table string, keys, payload map[string]any) (string, []any) {
+	return w.newUpdateBuilder(table, keys, payload).Build()
+}
+
+// newUpdateBuilder returns a builder of an SQL UPDATE statement of the payload columns of the rows with the keys.
+func (w *Writer) newUpdateBuilder(table string, keys, payload map[string]any) *sqlbuilder.UpdateBuilder {
 	up := sqlbuilder.NewUpdateBuilder()
 
-	up.Update(table)
+	up.Update(quoteTable(table))
 
 	setVal := make([]string, 0)
 	for key, val := range payload {
-		setVal = append(setVal, up.Assign(key, val))
+		setVal = append(setVal, up.Assign(quoteColumn(key), val))
 	}
 
 	up.Set(setVal...)
 
 	for key, val := range keys {
 		up.Where(
-			up.Equal(key, val),
+			up.Equal(quoteColumn(key), val),
 		)
 	}
 
-	return up.Build()
+	return up
 }
 
 func (w *Writer) buildInsertQuery(table string, columns []string, values []any) (string, []any) {
 	sb := sqlbuilder.NewInsertBuilder()
 
-	sb.InsertInto(table)
-	sb.Cols(columns...)
+	sb.InsertInto(quoteTable(table))
+	sb.Cols(quoteColumns(columns)...)
 	sb.Values(values...)
 
 	return sb.Build()
@@ -223,8 +718,12 @@ func (w *Writer) structurizeData(data opencdc.Data) (opencdc.StructuredData, err
 		return nil, nil
 	}
 
+	// decode numbers as json.Number, so they can be converted to column types without loss of precision.
+	decoder := json.NewDecoder(bytes.NewReader(data.Bytes()))
+	decoder.UseNumber()
+
 	structuredData := make(opencdc.StructuredData)
-	if err := json.Unmarshal(data.Bytes(), &structuredData); err != nil {
+	if err := decoder.Decode(&structuredData); err != nil {
 		return nil, fmt.Errorf("unmarshal data into structured data: %w", err)
 	}
 
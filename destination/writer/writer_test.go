@@ -0,0 +1,164 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/conduitio-labs/conduit-connector-db2/coltypes"
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestWriter_resolveKeys(t *testing.T) {
+	t.Parallel()
+
+	compositeKey := coltypes.TableInfo{
+		ColumnTypes: map[string]string{"ORDER_ID": "INTEGER", "LINE": "INTEGER", "NAME": "VARCHAR"},
+		PrimaryKeys: []string{"ORDER_ID", "LINE"},
+	}
+
+	tests := []struct {
+		name      string
+		tableInfo coltypes.TableInfo
+		key       opencdc.Data
+		payload   opencdc.StructuredData
+		want      opencdc.StructuredData
+		wantErr   bool
+	}{
+		{
+			name:      "structured key",
+			tableInfo: compositeKey,
+			key:       opencdc.RawData(`{"ORDER_ID":1,"LINE":2}`),
+			want:      opencdc.StructuredData{"ORDER_ID": int64(1), "LINE": int64(2)},
+		},
+		{
+			name:      "composite key from the payload",
+			tableInfo: compositeKey,
+			payload:   opencdc.StructuredData{"order_id": 1, "line": 2, "name": "pen"},
+			want:      opencdc.StructuredData{"ORDER_ID": int64(1), "LINE": int64(2)},
+		},
+		{
+			name:      "payload without a column of the composite key",
+			tableInfo: compositeKey,
+			payload:   opencdc.StructuredData{"order_id": 1, "name": "pen"},
+			wantErr:   true,
+		},
+		{
+			name:      "scalar key of a composite key",
+			tableInfo: compositeKey,
+			key:       opencdc.RawData("1"),
+			wantErr:   true,
+		},
+		{
+			name: "scalar key of a single column key",
+			tableInfo: coltypes.TableInfo{
+				ColumnTypes: map[string]string{"ID": "INTEGER"},
+				PrimaryKeys: []string{"ID"},
+			},
+			key:  opencdc.RawData("1"),
+			want: opencdc.StructuredData{"ID": int64(1)},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			w := &Writer{}
+
+			got, err := w.resolveKeys(context.Background(), opencdc.Record{Key: tt.key}, tt.tableInfo, tt.payload)
+			if tt.wantErr {
+				is.True(err != nil)
+
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+func TestWriter_withRefresh(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		err         error
+		wantCalls   int
+		wantRefresh bool
+	}{
+		{
+			name:        "undefined column",
+			err:         errors.New(`SQL0206N  "EMAIL" is not valid in the context where it is used.  SQLSTATE=42703`),
+			wantCalls:   2,
+			wantRefresh: true,
+		},
+		{
+			name:        "undefined table",
+			err:         errors.New(`SQL0204N  "DB2INST1.USERS" is an undefined name.  SQLSTATE=42704`),
+			wantCalls:   2,
+			wantRefresh: true,
+		},
+		{
+			name:      "other error",
+			err:       errors.New(`SQL0803N  One or more values in the INSERT statement are not valid.  SQLSTATE=23505`),
+			wantCalls: 1,
+		},
+		{
+			name:      "no error",
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			w := &Writer{
+				tables: &tableInfoCache{
+					tables: map[string]coltypes.TableInfo{"USERS": {ColumnTypes: map[string]string{"ID": "INTEGER"}}},
+				},
+			}
+
+			calls := 0
+			err := w.withRefresh(context.Background(), "USERS", func() error {
+				calls++
+				if calls == 1 {
+					return tt.err
+				}
+
+				return nil
+			})
+
+			is.Equal(calls, tt.wantCalls)
+			if tt.wantRefresh {
+				is.NoErr(err)
+			} else {
+				is.Equal(err, tt.err)
+			}
+
+			_, cached := w.tables.tables["USERS"]
+			is.Equal(cached, !tt.wantRefresh)
+		})
+	}
+}
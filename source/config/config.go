@@ -17,9 +17,13 @@
 package config
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/conduitio-labs/conduit-connector-db2/coltypes"
 	"github.com/conduitio-labs/conduit-connector-db2/common"
 )
 
@@ -36,28 +40,97 @@ type Config struct {
 	// PrimaryKeys list of column names should use for their `Key` fields.
 	PrimaryKeys []string `json:"primaryKeys"`
 	// Snapshot whether or not the plugin will take a snapshot of the entire table before starting cdc.
+	// Deprecated: use mode instead, it is only used if mode is not set.
 	Snapshot bool `json:"snapshot" default:"true"`
+	// Mode is a mode of reading the table: "snapshot" (reads the table once, without creating triggers),
+	// "cdc" (skips the snapshot and reads changes) or "snapshot+cdc" (reads the table and then its changes).
+	// By default, it is "snapshot+cdc", or "cdc" if snapshot is false, and "snapshot" for a query.
+	Mode string `json:"mode" validate:"inclusion=snapshot|cdc|snapshot+cdc"`
+	// DecimalFormat is a representation of DECIMAL values in the records: "string" (e.g. "123.45"),
+	// "scaled" (the unscaled integer, e.g. 12345 for DECIMAL(5,2)) or "avro" (Avro decimal bytes).
+	DecimalFormat string `json:"decimalFormat" default:"string" validate:"inclusion=string|scaled|avro"`
+	// TimeFormat is a representation of DATE, TIME and TIMESTAMP values in the records: "typed" (time values),
+	// "rfc3339" (strings with the session time zone offset) or "epochMillis" (milliseconds since the Unix epoch,
+	// milliseconds since midnight for TIME values).
+	TimeFormat string `json:"timeFormat" default:"typed" validate:"inclusion=typed|rfc3339|epochMillis"`
+	// LOBPolicy is a policy of reading CLOB, BLOB and DBCLOB columns: "include" (the whole value),
+	// "truncate" (the first lobTruncateBytes bytes), "hash" (the hex-encoded SHA-256 hash) or "exclude".
+	LOBPolicy string `json:"lobPolicy" default:"include" validate:"inclusion=include|truncate|hash|exclude"`
+	// LOBTruncateBytes is a number of bytes that the "truncate" policy keeps.
+	LOBTruncateBytes int `json:"lobTruncateBytes" default:"1048576" validate:"gt=0"`
+	// LOBColumns holds policies of individual LOB columns, which override lobPolicy and lobTruncateBytes.
+	LOBColumns map[string]LOBColumn `json:"lobColumns"`
+	// Isolation is an isolation level of the snapshot and CDC queries: "UR" (uncommitted read), "CS" (cursor
+	// stability), "RS" (read stability) or "RR" (repeatable read). By default, the isolation level of the
+	// connection is used.
+	Isolation string `json:"isolation" validate:"inclusion=UR|CS|RS|RR"`
+	// LockTimeout is the time the queries wait for a lock, it is set with SET CURRENT LOCK TIMEOUT and rounded up
+	// to seconds. By default, the LOCKTIMEOUT of the database is used. Only supported on DB2 for LUW.
+	LockTimeout time.Duration `json:"lockTimeout"`
+	// CurrentlyCommitted makes queries with the cursor stability isolation read the currently committed version
+	// of rows that are locked by writers instead of waiting for the locks. Not supported on DB2 for IBM i.
+	CurrentlyCommitted bool `json:"currentlyCommitted"`
+	// Filter is an SQL predicate which rows must satisfy to be read, e.g. "REGION = 'EU' AND DELETED = 0".
+	// It is added to the WHERE clause of the snapshot queries.
+	Filter string `json:"filter"`
+	// FilterTriggers compiles the filter into the WHEN clause of the CDC triggers, so changes of rows which don't
+	// satisfy it never reach the tracking table. Updates and inserts are checked against the new row,
+	// deletes against the old row.
+	FilterTriggers bool `json:"filterTriggers"`
+	// Query is a SELECT query whose result is read instead of the table, e.g. a join or a view. The query is read
+	// in batches ordered by orderingColumn, which must be a column of its result, and only a snapshot is taken,
+	// there is no CDC. The table names the collection of the records. Only supported on DB2 for LUW.
+	Query string `json:"query"`
 }
 
-// Init initializes common configuration and sets uppercase "orderingColumn", "columns", and "primaryKeys".
+// Modes of reading the table.
+const (
+	ModeSnapshot    = "snapshot"
+	ModeCDC         = "cdc"
+	ModeSnapshotCDC = "snapshot+cdc"
+)
+
+// maxLockTimeout is the maximum value of the CURRENT LOCK TIMEOUT special register.
+const maxLockTimeout = 32767 * time.Second
+
+// LOBColumn holds a policy of reading a LOB column.
+type LOBColumn struct {
+	// Policy is a policy of reading the column: "include", "truncate", "hash" or "exclude".
+	Policy string `json:"policy" validate:"inclusion=include|truncate|hash|exclude"`
+	// TruncateBytes is a number of bytes that the "truncate" policy keeps, by default lobTruncateBytes.
+	TruncateBytes int `json:"truncateBytes"`
+}
+
+// Init initializes common configuration and normalizes "orderingColumn", "columns", "primaryKeys"
+// and "lobColumns" names: ordinary identifiers are folded to uppercase and identifiers in double quotes
+// keep their case.
 func (c Config) Init() Config {
 	c.Configuration = c.Configuration.Init()
-	c.OrderingColumn = strings.ToUpper(c.OrderingColumn)
+	c.OrderingColumn = common.NormalizeIdentifier(c.OrderingColumn)
+	c.Query = strings.TrimSpace(c.Query)
 
 	if len(c.Columns) > 0 {
-		upperColumns := make([]string, len(c.Columns))
+		columns := make([]string, len(c.Columns))
 		for i, col := range c.Columns {
-			upperColumns[i] = strings.ToUpper(col)
+			columns[i] = common.NormalizeIdentifier(col)
 		}
-		c.Columns = upperColumns
+		c.Columns = columns
 	}
 
 	if len(c.PrimaryKeys) > 0 {
-		upperKeys := make([]string, len(c.PrimaryKeys))
+		keys := make([]string, len(c.PrimaryKeys))
 		for i, key := range c.PrimaryKeys {
-			upperKeys[i] = strings.ToUpper(key)
+			keys[i] = common.NormalizeIdentifier(key)
 		}
-		c.PrimaryKeys = upperKeys
+		c.PrimaryKeys = keys
+	}
+
+	if len(c.LOBColumns) > 0 {
+		lobColumns := make(map[string]LOBColumn, len(c.LOBColumns))
+		for col, lobColumn := range c.LOBColumns {
+			lobColumns[common.NormalizeIdentifier(col)] = lobColumn
+		}
+		c.LOBColumns = lobColumns
 	}
 
 	return c
@@ -76,6 +149,10 @@ func (c *Config) Validate() error {
 		return common.NewLessThanError(ConfigOrderingColumn, common.MaxConfigStringLength)
 	}
 
+	if err = common.ValidateIdentifier(c.OrderingColumn); err != nil {
+		return fmt.Errorf("%q: %w", ConfigOrderingColumn, err)
+	}
+
 	// Validate Columns.
 	if len(c.Columns) > 0 {
 		// Check if Columns contain OrderingColumn when specified
@@ -84,6 +161,9 @@ func (c *Config) Validate() error {
 			if len(col) > 128 {
 				return fmt.Errorf(`column %q length must be less than or equal to 128 characters`, col)
 			}
+			if err = common.ValidateIdentifier(col); err != nil {
+				return fmt.Errorf("%q: %w", ConfigColumns, err)
+			}
 			if col == c.OrderingColumn {
 				hasOrderingColumn = true
 			}
@@ -99,7 +179,188 @@ func (c *Config) Validate() error {
 			return fmt.Errorf(
 				`primaryKey %q length must be less than or equal to 128 characters`, key)
 		}
+
+		if err = common.ValidateIdentifier(key); err != nil {
+			return fmt.Errorf("%q: %w", ConfigPrimaryKeys, err)
+		}
+	}
+
+	// Validate LOBColumns.
+	for col, lobColumn := range c.LOBColumns {
+		switch coltypes.LOBPolicyType(lobColumn.Policy) {
+		case coltypes.LOBPolicyInclude:
+		case coltypes.LOBPolicyTruncate, coltypes.LOBPolicyHash, coltypes.LOBPolicyExclude:
+			if col == c.OrderingColumn || slices.Contains(c.PrimaryKeys, col) {
+				return fmt.Errorf(`lob policy of orderingColumn or primaryKey %q must be "include"`, col)
+			}
+		default:
+			return fmt.Errorf(`lob policy of column %q must be one of "include", "truncate", "hash", "exclude"`, col)
+		}
+
+		if lobColumn.TruncateBytes < 0 {
+			return fmt.Errorf(`lob truncateBytes of column %q must be greater than 0`, col)
+		}
+	}
+
+	// Validate Filter.
+	if c.Filter != "" {
+		if err = common.ValidatePredicate(c.Filter); err != nil {
+			return fmt.Errorf("%q: %w", ConfigFilter, err)
+		}
+	} else if c.FilterTriggers {
+		return fmt.Errorf("%q requires %q", ConfigFilterTriggers, ConfigFilter)
+	}
+
+	if err = c.validateMode(); err != nil {
+		return err
+	}
+
+	if err = c.validateQuery(); err != nil {
+		return err
+	}
+
+	return c.validateLocking()
+}
+
+// ReadMode returns the mode of reading the table. If mode is not set, it is "cdc" if snapshot is false,
+// "snapshot" for a query and "snapshot+cdc" otherwise, which was the behavior before the source had modes.
+func (c Config) ReadMode() string {
+	switch {
+	case c.Mode != "":
+		return c.Mode
+	case !c.Snapshot:
+		return ModeCDC
+	case c.Query != "":
+		return ModeSnapshot
+	default:
+		return ModeSnapshotCDC
+	}
+}
+
+// validateMode validates the mode against the options which require a snapshot or CDC.
+func (c *Config) validateMode() error {
+	mode := c.ReadMode()
+
+	switch mode {
+	case ModeSnapshot:
+		if c.FilterTriggers {
+			return fmt.Errorf("%q is not supported in the %q %q, triggers are not created",
+				ConfigFilterTriggers, mode, ConfigMode)
+		}
+	case ModeCDC, ModeSnapshotCDC:
+	default:
+		return fmt.Errorf("%q must be one of %q, %q, %q", ConfigMode, ModeSnapshot, ModeCDC, ModeSnapshotCDC)
+	}
+
+	if !c.Snapshot && mode != ModeCDC {
+		return fmt.Errorf("%q false can't be used with the %q %q", ConfigSnapshot, mode, ConfigMode)
+	}
+
+	return nil
+}
+
+// validateQuery validates the query of the custom query mode, which only takes a snapshot.
+func (c *Config) validateQuery() error {
+	if c.Query == "" {
+		return nil
+	}
+
+	if err := common.ValidateSelect(c.Query); err != nil {
+		return fmt.Errorf("%q: %w", ConfigQuery, err)
+	}
+
+	// the result columns of queries are described with SYSPROC.ADMIN_CMD.
+	if err := c.Dialect().ValidateFeature(common.FeatureAdminCmd); err != nil {
+		return fmt.Errorf("%q: %w", ConfigQuery, err)
+	}
+
+	if c.ReadMode() != ModeSnapshot {
+		return fmt.Errorf("%q requires the %q %q, CDC is not supported for queries", ConfigQuery, ModeSnapshot,
+			ConfigMode)
+	}
+
+	return nil
+}
+
+// validateLocking validates the isolation level and the lock options against the platform.
+func (c *Config) validateLocking() error {
+	dialect := c.Dialect()
+
+	if err := dialect.ValidateIsolation(common.Isolation(c.Isolation)); err != nil {
+		return fmt.Errorf("%q: %w", ConfigIsolation, err)
+	}
+
+	if c.LockTimeout < 0 || c.LockTimeout > maxLockTimeout {
+		return fmt.Errorf("%q must be between 0 and %s", ConfigLockTimeout, maxLockTimeout)
+	}
+
+	if c.LockTimeout > 0 {
+		if err := dialect.ValidateFeature(common.FeatureLockTimeout); err != nil {
+			return fmt.Errorf("%q: %w", ConfigLockTimeout, err)
+		}
+	}
+
+	if c.CurrentlyCommitted {
+		switch common.Isolation(c.Isolation) {
+		case common.IsolationDefault, common.IsolationCS:
+		default:
+			return fmt.Errorf("%q requires the %q %q", ConfigCurrentlyCommitted, common.IsolationCS, ConfigIsolation)
+		}
+
+		if err := dialect.ValidateFeature(common.FeatureCurrentlyCommitted); err != nil {
+			return fmt.Errorf("%q: %w", ConfigCurrentlyCommitted, err)
+		}
 	}
 
 	return nil
 }
+
+// ConnectionString returns the connection string of the source, with the CLI keyword of the currently committed
+// semantics if "currentlyCommitted" is set.
+func (c Config) ConnectionString(ctx context.Context) (string, error) {
+	conn, err := c.Configuration.ConnectionString(ctx, common.RoleSource)
+	if err != nil || !c.CurrentlyCommitted {
+		return conn, err //nolint:wrapcheck // the error of the common configuration is returned as is
+	}
+
+	return common.AppendKeywords(conn, common.Keyword{
+		Name:  "ConcurrentAccessResolution",
+		Value: "UseCurrentlyCommitted",
+	}), nil
+}
+
+// SessionStatements returns the statements that prepare the sessions of the source connections.
+func (c Config) SessionStatements() []string {
+	if c.LockTimeout <= 0 {
+		return nil
+	}
+
+	seconds := (c.LockTimeout + time.Second - 1) / time.Second
+
+	return []string{fmt.Sprintf("SET CURRENT LOCK TIMEOUT %d", seconds)}
+}
+
+// LOBPolicies returns policies of reading LOB columns.
+func (c Config) LOBPolicies() coltypes.LOBPolicies {
+	policies := coltypes.LOBPolicies{
+		Default: coltypes.LOBPolicy{
+			Type:          coltypes.LOBPolicyType(c.LOBPolicy),
+			TruncateBytes: c.LOBTruncateBytes,
+		},
+		Columns: make(map[string]coltypes.LOBPolicy, len(c.LOBColumns)),
+	}
+
+	for col, lobColumn := range c.LOBColumns {
+		truncateBytes := lobColumn.TruncateBytes
+		if truncateBytes == 0 {
+			truncateBytes = c.LOBTruncateBytes
+		}
+
+		policies.Columns[col] = coltypes.LOBPolicy{
+			Type:          coltypes.LOBPolicyType(lobColumn.Policy),
+			TruncateBytes: truncateBytes,
+		}
+	}
+
+	return policies
+}
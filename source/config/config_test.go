@@ -15,9 +15,12 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/conduitio-labs/conduit-connector-db2/coltypes"
 	"github.com/conduitio-labs/conduit-connector-db2/common"
 	"github.com/matryer/is"
 )
@@ -102,6 +105,299 @@ func TestValidateConfig(t *testing.T) {
 			},
 			wantErr: fmt.Errorf(`columns must contain orderingColumn "updated_at"`),
 		},
+		{
+			name: "success_lob_columns",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				LOBColumns: map[string]LOBColumn{
+					"DOC":   {Policy: "truncate", TruncateBytes: 1024},
+					"IMAGE": {Policy: "hash"},
+				},
+			},
+		},
+		{
+			name: "failure_lob_column_invalid_policy",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				LOBColumns:     map[string]LOBColumn{"DOC": {TruncateBytes: 1024}},
+			},
+			wantErr: fmt.Errorf(`lob policy of column "DOC" must be one of "include", "truncate", "hash", "exclude"`),
+		},
+		{
+			name: "failure_lob_column_excludes_ordering_column",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				LOBColumns:     map[string]LOBColumn{"ID": {Policy: "exclude"}},
+			},
+			wantErr: fmt.Errorf(`lob policy of orderingColumn or primaryKey "ID" must be "include"`),
+		},
+		{
+			name: "success_locking",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+					Platform:   "luw",
+				},
+				OrderingColumn:     "ID",
+				BatchSize:          defaultBatchSize,
+				Isolation:          "CS",
+				LockTimeout:        10 * time.Second,
+				CurrentlyCommitted: true,
+			},
+		},
+		{
+			name: "failure_single_connection",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection:         testConnection,
+					MaxOpenConnections: 1,
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+			},
+			wantErr: fmt.Errorf(`"maxOpenConnections" must be 0 or at least 2`),
+		},
+		{
+			name: "failure_lock_timeout_on_zos",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+					Platform:   "zos",
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				LockTimeout:    10 * time.Second,
+			},
+			wantErr: fmt.Errorf(`"lockTimeout": "CURRENT LOCK TIMEOUT" on "zos": feature is not supported by the platform`),
+		},
+		{
+			name: "failure_negative_lock_timeout",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				LockTimeout:    -time.Second,
+			},
+			wantErr: fmt.Errorf(`"lockTimeout" must be between 0 and 9h6m7s`),
+		},
+		{
+			name: "failure_currently_committed_with_uncommitted_read",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+				},
+				OrderingColumn:     "ID",
+				BatchSize:          defaultBatchSize,
+				Isolation:          "UR",
+				CurrentlyCommitted: true,
+			},
+			wantErr: fmt.Errorf(`"currentlyCommitted" requires the "CS" "isolation"`),
+		},
+		{
+			name: "failure_currently_committed_on_ibmi",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+					Platform:   "ibmi",
+				},
+				OrderingColumn:     "ID",
+				BatchSize:          defaultBatchSize,
+				CurrentlyCommitted: true,
+			},
+			wantErr: fmt.Errorf(`"currentlyCommitted": "currently committed" on "ibmi": feature is not supported by the platform`),
+		},
+		{
+			name: "success_filter_triggers",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				Filter:         `REGION = 'EU; --' AND "Deleted" = 0`,
+				FilterTriggers: true,
+			},
+		},
+		{
+			name: "failure_filter_with_statement",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				Filter:         "1 = 1; DROP TABLE USERS",
+			},
+			wantErr: fmt.Errorf(`"filter": ";" at 5 is not allowed: invalid predicate`),
+		},
+		{
+			name: "failure_filter_unbalanced_parentheses",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				Filter:         "(ID > 1) OR (ID < 0",
+			},
+			wantErr: fmt.Errorf(`"filter": unbalanced parentheses: invalid predicate`),
+		},
+		{
+			name: "failure_filter_triggers_without_filter",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				FilterTriggers: true,
+			},
+			wantErr: fmt.Errorf(`"filterTriggers" requires "filter"`),
+		},
+		{
+			name: "success_query",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				Snapshot:       true,
+				Query:          "SELECT o.ID, c.NAME FROM ORDERS o JOIN CUSTOMERS c ON c.ID = o.CUSTOMER_ID",
+			},
+		},
+		{
+			name: "failure_query_not_select",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				Snapshot:       true,
+				Query:          "DELETE FROM ORDERS",
+			},
+			wantErr: fmt.Errorf(`"query": query must start with SELECT: invalid predicate`),
+		},
+		{
+			name: "failure_query_with_cdc",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				Query:          "SELECT ID FROM ORDERS",
+			},
+			wantErr: fmt.Errorf(`"query" requires the "snapshot" "mode", CDC is not supported for queries`),
+		},
+		{
+			name: "failure_query_with_filter_triggers",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				Snapshot:       true,
+				Query:          "SELECT ID FROM ORDERS",
+				Filter:         "ID > 10",
+				FilterTriggers: true,
+			},
+			wantErr: fmt.Errorf(`"filterTriggers" is not supported in the "snapshot" "mode", triggers are not created`),
+		},
+		{
+			name: "failure_query_on_zos",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+					Platform:   "zos",
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				Snapshot:       true,
+				Query:          "SELECT ID FROM ORDERS",
+			},
+			wantErr: fmt.Errorf(`"query": "SYSPROC.ADMIN_CMD" on "zos": feature is not supported by the platform`),
+		},
+		{
+			name: "success_snapshot_mode",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				Snapshot:       true,
+				Mode:           "snapshot",
+				Filter:         "ID > 10",
+			},
+		},
+		{
+			name: "success_cdc_mode_with_snapshot_default",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				Snapshot:       true,
+				Mode:           "cdc",
+			},
+		},
+		{
+			name: "failure_snapshot_off_in_snapshot_mode",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				Mode:           "snapshot+cdc",
+			},
+			wantErr: fmt.Errorf(`"snapshot" false can't be used with the "snapshot+cdc" "mode"`),
+		},
+		{
+			name: "failure_query_in_cdc_mode",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				Snapshot:       true,
+				Mode:           "cdc",
+				Query:          "SELECT ID FROM ORDERS",
+			},
+			wantErr: fmt.Errorf(`"query" requires the "snapshot" "mode", CDC is not supported for queries`),
+		},
+		{
+			name: "failure_unknown_mode",
+			in: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+				},
+				OrderingColumn: "ID",
+				BatchSize:      defaultBatchSize,
+				Snapshot:       true,
+				Mode:           "full",
+			},
+			wantErr: fmt.Errorf(`"mode" must be one of "snapshot", "cdc", "snapshot+cdc"`),
+		},
 	}
 
 	for _, tt := range tests {
@@ -153,6 +449,29 @@ func TestConfigInit(t *testing.T) {
 				BatchSize:      defaultBatchSize,
 			},
 		},
+		{
+			name: "keep_delimited_identifiers",
+			input: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+					Table:      `"Test_Table"`,
+				},
+				OrderingColumn: `"updatedAt"`,
+				Columns:        []string{`"Id"`, "name", `"updatedAt"`},
+				PrimaryKeys:    []string{`"Id"`},
+				BatchSize:      defaultBatchSize,
+			},
+			expected: Config{
+				Configuration: common.Configuration{
+					Connection: testConnection,
+					Table:      "Test_Table",
+				},
+				OrderingColumn: "updatedAt",
+				Columns:        []string{"Id", "NAME", "updatedAt"},
+				PrimaryKeys:    []string{"Id"},
+				BatchSize:      defaultBatchSize,
+			},
+		},
 		{
 			name: "empty_columns_and_primary_keys",
 			input: Config{
@@ -196,3 +515,93 @@ func TestConfigInit(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_LOBPolicies(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	cfg := Config{
+		LOBPolicy:        "truncate",
+		LOBTruncateBytes: 100,
+		LOBColumns: map[string]LOBColumn{
+			"doc":   {Policy: "truncate"},
+			"image": {Policy: "truncate", TruncateBytes: 10},
+		},
+	}.Init()
+
+	is.Equal(cfg.LOBPolicies(), coltypes.LOBPolicies{
+		Default: coltypes.LOBPolicy{Type: coltypes.LOBPolicyTruncate, TruncateBytes: 100},
+		Columns: map[string]coltypes.LOBPolicy{
+			"DOC":   {Type: coltypes.LOBPolicyTruncate, TruncateBytes: 100},
+			"IMAGE": {Type: coltypes.LOBPolicyTruncate, TruncateBytes: 10},
+		},
+	})
+}
+
+func TestConfig_ReadMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   Config
+		want string
+	}{
+		{
+			name: "default",
+			in:   Config{Snapshot: true},
+			want: ModeSnapshotCDC,
+		},
+		{
+			name: "snapshot off",
+			in:   Config{Snapshot: false},
+			want: ModeCDC,
+		},
+		{
+			name: "query",
+			in:   Config{Snapshot: true, Query: "SELECT ID FROM ORDERS"},
+			want: ModeSnapshot,
+		},
+		{
+			name: "explicit mode",
+			in:   Config{Snapshot: true, Mode: ModeSnapshot},
+			want: ModeSnapshot,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			is.Equal(tt.in.ReadMode(), tt.want)
+		})
+	}
+}
+
+func TestConfig_Locking(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	cfg := Config{
+		Configuration: common.Configuration{
+			Connection: "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd;",
+		},
+	}
+
+	cfg.ClientApplName = "orders-snapshot"
+	cfg.ClientAccounting = "team=payments"
+
+	conn, err := cfg.ConnectionString(context.Background())
+	is.NoErr(err)
+	is.Equal(conn, cfg.Connection+"ClientApplName=orders-snapshot;ClientAcctStr={team=payments};")
+	is.Equal(len(cfg.SessionStatements()), 0)
+
+	cfg.CurrentlyCommitted = true
+	cfg.LockTimeout = 1500 * time.Millisecond
+
+	conn, err = cfg.ConnectionString(context.Background())
+	is.NoErr(err)
+	is.Equal(conn, "HOSTNAME=localhost;DATABASE=testdb;PORT=50000;UID=DB2INST1;PWD=pwd;"+
+		"ClientApplName=orders-snapshot;ClientAcctStr={team=payments};ConcurrentAccessResolution=UseCurrentlyCommitted;")
+	is.Equal(cfg.SessionStatements(), []string{"SET CURRENT LOCK TIMEOUT 2"})
+}
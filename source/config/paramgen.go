@@ -8,13 +8,50 @@ import (
 )
 
 const (
-	ConfigBatchSize      = "batchSize"
-	ConfigColumns        = "columns"
-	ConfigConnection     = "connection"
-	ConfigOrderingColumn = "orderingColumn"
-	ConfigPrimaryKeys    = "primaryKeys"
-	ConfigSnapshot       = "snapshot"
-	ConfigTable          = "table"
+	ConfigBatchSize               = "batchSize"
+	ConfigClientAccounting        = "clientAccounting"
+	ConfigClientApplName          = "clientApplName"
+	ConfigClientUserID            = "clientUserID"
+	ConfigClientWrkstnName        = "clientWrkstnName"
+	ConfigColumns                 = "columns"
+	ConfigConnection              = "connection"
+	ConfigConnectionMaxLifetime   = "connectionMaxLifetime"
+	ConfigCurrentSchema           = "currentSchema"
+	ConfigCurrentlyCommitted      = "currentlyCommitted"
+	ConfigDatabase                = "database"
+	ConfigDecimalFormat           = "decimalFormat"
+	ConfigFilter                  = "filter"
+	ConfigFilterTriggers          = "filterTriggers"
+	ConfigHost                    = "host"
+	ConfigIsolation               = "isolation"
+	ConfigLobColumnsPolicy        = "lobColumns.*.policy"
+	ConfigLobColumnsTruncateBytes = "lobColumns.*.truncateBytes"
+	ConfigLobPolicy               = "lobPolicy"
+	ConfigLobTruncateBytes        = "lobTruncateBytes"
+	ConfigLockTimeout             = "lockTimeout"
+	ConfigMaxIdleConnections      = "maxIdleConnections"
+	ConfigMaxOpenConnections      = "maxOpenConnections"
+	ConfigMaxRetries              = "maxRetries"
+	ConfigMode                    = "mode"
+	ConfigOrderingColumn          = "orderingColumn"
+	ConfigPassword                = "password"
+	ConfigPasswordEnv             = "passwordEnv"
+	ConfigPasswordFile            = "passwordFile"
+	ConfigPlatform                = "platform"
+	ConfigPort                    = "port"
+	ConfigPrimaryKeys             = "primaryKeys"
+	ConfigQuery                   = "query"
+	ConfigRetryBackoff            = "retryBackoff"
+	ConfigRetryMaxBackoff         = "retryMaxBackoff"
+	ConfigSnapshot                = "snapshot"
+	ConfigSsl                     = "ssl"
+	ConfigSslClientKeystash       = "sslClientKeystash"
+	ConfigSslClientKeystore       = "sslClientKeystore"
+	ConfigSslServerCertificate    = "sslServerCertificate"
+	ConfigTable                   = "table"
+	ConfigTimeFormat              = "timeFormat"
+	ConfigTimezone                = "timezone"
+	ConfigUser                    = "user"
 )
 
 func (Config) Parameters() map[string]config.Parameter {
@@ -28,6 +65,30 @@ func (Config) Parameters() map[string]config.Parameter {
 				config.ValidationLessThan{V: 100001},
 			},
 		},
+		ConfigClientAccounting: {
+			Default:     "",
+			Description: "ClientAccounting is the CLIENT_ACCTNG of the sessions, by default the connector name and version.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigClientApplName: {
+			Default:     "",
+			Description: "ClientApplName is the CLIENT_APPLNAME of the sessions, by default \"conduit-connector-db2-source\"\nor \"conduit-connector-db2-destination\".",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigClientUserID: {
+			Default:     "",
+			Description: "ClientUserID is the CLIENT_USERID of the sessions, by default the pipeline ID.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigClientWrkstnName: {
+			Default:     "",
+			Description: "ClientWrkstnName is the CLIENT_WRKSTNNAME of the sessions, by default the connector ID.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
 		ConfigColumns: {
 			Default:     "",
 			Description: "Columns  list of column names that should be included in each Record's payload.",
@@ -36,10 +97,134 @@ func (Config) Parameters() map[string]config.Parameter {
 		},
 		ConfigConnection: {
 			Default:     "",
-			Description: "Connection string connection to DB2 database.",
+			Description: "Connection string connection to DB2 database. The connection can be configured with the \"host\", \"port\",\n\"database\", \"user\" and password fields instead.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigConnectionMaxLifetime: {
+			Default:     "30m",
+			Description: "ConnectionMaxLifetime is a maximum time a connection is reused, 0 means connections are reused forever.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigCurrentSchema: {
+			Default:     "",
+			Description: "CurrentSchema is the schema of unqualified table names, by default the schema of the user.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigCurrentlyCommitted: {
+			Default:     "",
+			Description: "CurrentlyCommitted makes queries with the cursor stability isolation read the currently committed version\nof rows that are locked by writers instead of waiting for the locks. Not supported on DB2 for IBM i.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigDatabase: {
+			Default:     "",
+			Description: "Database is a name of the database.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigDecimalFormat: {
+			Default:     "string",
+			Description: "DecimalFormat is a representation of DECIMAL values in the records: \"string\" (e.g. \"123.45\"),\n\"scaled\" (the unscaled integer, e.g. 12345 for DECIMAL(5,2)) or \"avro\" (Avro decimal bytes).",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{
-				config.ValidationRequired{},
+				config.ValidationInclusion{List: []string{"string", "scaled", "avro"}},
+			},
+		},
+		ConfigFilter: {
+			Default:     "",
+			Description: "Filter is an SQL predicate which rows must satisfy to be read, e.g. \"REGION = 'EU' AND DELETED = 0\".\nIt is added to the WHERE clause of the snapshot queries.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigFilterTriggers: {
+			Default:     "",
+			Description: "FilterTriggers compiles the filter into the WHEN clause of the CDC triggers, so changes of rows which don't\nsatisfy it never reach the tracking table. Updates and inserts are checked against the new row,\ndeletes against the old row.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigHost: {
+			Default:     "",
+			Description: "Host is a host name or an IP address of the DB2 server, it is used instead of the \"connection\" string.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigIsolation: {
+			Default:     "",
+			Description: "Isolation is an isolation level of the snapshot and CDC queries: \"UR\" (uncommitted read), \"CS\" (cursor\nstability), \"RS\" (read stability) or \"RR\" (repeatable read). By default, the isolation level of the\nconnection is used.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"UR", "CS", "RS", "RR"}},
+			},
+		},
+		ConfigLobColumnsPolicy: {
+			Default:     "",
+			Description: "Policy is a policy of reading the column: \"include\", \"truncate\", \"hash\" or \"exclude\".",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"include", "truncate", "hash", "exclude"}},
+			},
+		},
+		ConfigLobColumnsTruncateBytes: {
+			Default:     "",
+			Description: "TruncateBytes is a number of bytes that the \"truncate\" policy keeps, by default lobTruncateBytes.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{},
+		},
+		ConfigLobPolicy: {
+			Default:     "include",
+			Description: "LOBPolicy is a policy of reading CLOB, BLOB and DBCLOB columns: \"include\" (the whole value),\n\"truncate\" (the first lobTruncateBytes bytes), \"hash\" (the hex-encoded SHA-256 hash) or \"exclude\".",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"include", "truncate", "hash", "exclude"}},
+			},
+		},
+		ConfigLobTruncateBytes: {
+			Default:     "1048576",
+			Description: "LOBTruncateBytes is a number of bytes that the \"truncate\" policy keeps.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
+			},
+		},
+		ConfigLockTimeout: {
+			Default:     "",
+			Description: "LockTimeout is the time the queries wait for a lock, it is set with SET CURRENT LOCK TIMEOUT and rounded up\nto seconds. By default, the LOCKTIMEOUT of the database is used. Only supported on DB2 for LUW.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigMaxIdleConnections: {
+			Default:     "2",
+			Description: "MaxIdleConnections is a maximum number of idle connections kept open for reuse.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigMaxOpenConnections: {
+			Default:     "4",
+			Description: "MaxOpenConnections is a maximum number of open connections of the connector, 0 means no limit.\nThe connector uses up to two connections at the same time, so the limit must be 0 or at least 2.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigMaxRetries: {
+			Default:     "3",
+			Description: "MaxRetries is a maximum number of retries of reads and writes failed with a transient error: a deadlock,\na lock timeout or a communication failure, after which the connector reconnects. 0 disables retries.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigMode: {
+			Default:     "",
+			Description: "Mode is a mode of reading the table: \"snapshot\" (reads the table once, without creating triggers),\n\"cdc\" (skips the snapshot and reads changes) or \"snapshot+cdc\" (reads the table and then its changes).\nBy default, it is \"snapshot+cdc\", or \"cdc\" if snapshot is false, and \"snapshot\" for a query.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"snapshot", "cdc", "snapshot+cdc"}},
 			},
 		},
 		ConfigOrderingColumn: {
@@ -50,18 +235,95 @@ func (Config) Parameters() map[string]config.Parameter {
 				config.ValidationRequired{},
 			},
 		},
+		ConfigPassword: {
+			Default:     "",
+			Description: "Password is a password of the user.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigPasswordEnv: {
+			Default:     "",
+			Description: "PasswordEnv is a name of an environment variable with the password of the user.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigPasswordFile: {
+			Default:     "",
+			Description: "PasswordFile is a path of a file with the password of the user, a trailing line break is ignored.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigPlatform: {
+			Default:     "luw",
+			Description: "Platform is the DB2 platform of the database, which selects the SQL dialect of the queries:\n\"luw\" (DB2 for Linux, UNIX and Windows), \"zos\" (DB2 for z/OS) or \"ibmi\" (DB2 for IBM i).\nOnly \"luw\" is fully supported, options which rely on statements of other platforms are rejected.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"luw", "zos", "ibmi"}},
+			},
+		},
+		ConfigPort: {
+			Default:     "50000",
+			Description: "Port is a port of the DB2 server.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
+				config.ValidationLessThan{V: 65536},
+			},
+		},
 		ConfigPrimaryKeys: {
 			Default:     "",
 			Description: "PrimaryKeys list of column names should use for their `Key` fields.",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{},
 		},
+		ConfigQuery: {
+			Default:     "",
+			Description: "Query is a SELECT query whose result is read instead of the table, e.g. a join or a view. The query is read\nin batches ordered by orderingColumn, which must be a column of its result, and only a snapshot is taken,\nthere is no CDC. The table names the collection of the records. Only supported on DB2 for LUW.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigRetryBackoff: {
+			Default:     "1s",
+			Description: "RetryBackoff is the time before the first retry, it is doubled for every next retry.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigRetryMaxBackoff: {
+			Default:     "30s",
+			Description: "RetryMaxBackoff is the maximum time between retries.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
 		ConfigSnapshot: {
 			Default:     "true",
-			Description: "Snapshot whether or not the plugin will take a snapshot of the entire table before starting cdc.",
+			Description: "Snapshot whether or not the plugin will take a snapshot of the entire table before starting cdc.\nDeprecated: use mode instead, it is only used if mode is not set.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigSsl: {
+			Default:     "",
+			Description: "SSL enables TLS connections to the server (SECURITY=SSL).",
 			Type:        config.ParameterTypeBool,
 			Validations: []config.Validation{},
 		},
+		ConfigSslClientKeystash: {
+			Default:     "",
+			Description: "SSLClientKeystash is a path of the stash file (.sth) with the password of the client key database.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigSslClientKeystore: {
+			Default:     "",
+			Description: "SSLClientKeystore is a path of the key database (.kdb) with the client certificate\nand trusted certificates.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigSslServerCertificate: {
+			Default:     "",
+			Description: "SSLServerCertificate is a path of the certificate of the server or of the CA that signed it.\nBy default, the certificates of the client key database are trusted.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
 		ConfigTable: {
 			Default:     "",
 			Description: "Table is a name of the table that the connector should write to or read from.",
@@ -70,5 +332,25 @@ func (Config) Parameters() map[string]config.Parameter {
 				config.ValidationRequired{},
 			},
 		},
+		ConfigTimeFormat: {
+			Default:     "typed",
+			Description: "TimeFormat is a representation of DATE, TIME and TIMESTAMP values in the records: \"typed\" (time values),\n\"rfc3339\" (strings with the session time zone offset) or \"epochMillis\" (milliseconds since the Unix epoch,\nmilliseconds since midnight for TIME values).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"typed", "rfc3339", "epochMillis"}},
+			},
+		},
+		ConfigTimezone: {
+			Default:     "",
+			Description: "Timezone is a time zone of the DB2 session, which is used to interpret DATE, TIME and TIMESTAMP values\nwithout time zone, e.g. \"UTC\" or \"Europe/Berlin\". By default, the local time zone of the connector is used.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigUser: {
+			Default:     "",
+			Description: "User is a name of the user the connector connects as.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
 	}
 }
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/conduitio-labs/conduit-connector-db2/coltypes"
+	"github.com/conduitio-labs/conduit-connector-db2/common"
 	"github.com/conduitio-labs/conduit-connector-db2/source/position"
 	"github.com/conduitio/conduit-commons/opencdc"
 	sdk "github.com/conduitio/conduit-connector-sdk"
@@ -42,7 +43,7 @@ type trackingTableService struct {
 	stopCh chan struct{}
 	// channel for errors.
 	errCh chan error
-	// channel for notify that all queries finished and db can be closed.
+	// channel for notify that all queries finished and the pool can be closed.
 	canCloseCh chan struct{}
 	// idsForRemoving - ids of rows what need to clear.
 	idsForRemoving []any
@@ -83,8 +84,16 @@ type cdcIterator struct {
 	batchSize int
 	// position last recorded position.
 	position *position.Position
-	// columnTypes column types from table.
-	columnTypes map[string]string
+	// tableInfo information about column types from table.
+	tableInfo coltypes.TableInfo
+	// transformOpts options for converting row values.
+	transformOpts coltypes.TransformOptions
+	// mode mode of the source, which is saved to positions.
+	mode Mode
+	// dialect SQL dialect of the DB2 platform.
+	dialect common.Dialect
+	// isolation isolation level of the queries.
+	isolation common.Isolation
 }
 
 type cdcParams struct {
@@ -94,8 +103,12 @@ type cdcParams struct {
 	keys          []string
 	columns       []string
 	batchSize     int
-	columnTypes   map[string]string
+	tableInfo     coltypes.TableInfo
+	transformOpts coltypes.TransformOptions
 	position      *position.Position
+	mode          Mode
+	dialect       common.Dialect
+	isolation     common.Isolation
 }
 
 // newCDCIterator create new cdc iterator.
@@ -115,7 +128,11 @@ func newCDCIterator(
 		keys:          params.keys,
 		batchSize:     params.batchSize,
 		position:      params.position,
-		columnTypes:   params.columnTypes,
+		tableInfo:     params.tableInfo,
+		transformOpts: params.transformOpts,
+		mode:          params.mode,
+		dialect:       params.dialect,
+		isolation:     params.isolation,
 		tableSrv:      newTrackingTableService(),
 	}
 
@@ -149,7 +166,7 @@ func (i *cdcIterator) Next(ctx context.Context) (opencdc.Record, error) {
 		return opencdc.Record{}, fmt.Errorf("scan rows: %w", err)
 	}
 
-	transformedRow, err := coltypes.TransformRow(ctx, row, i.columnTypes)
+	transformedRow, err := coltypes.TransformRow(ctx, row, i.tableInfo, i.transformOpts)
 	if err != nil {
 		return opencdc.Record{}, fmt.Errorf("transform row column types: %w", err)
 	}
@@ -168,6 +185,7 @@ func (i *cdcIterator) Next(ctx context.Context) (opencdc.Record, error) {
 
 	pos := position.Position{
 		IteratorType: position.TypeCDC,
+		Mode:         string(i.mode),
 		CDCLastID:    int(id),
 		SuffixName:   i.trackingTable[len(i.trackingTable)-6:],
 	}
@@ -216,7 +234,7 @@ func (i *cdcIterator) Next(ctx context.Context) (opencdc.Record, error) {
 	}
 }
 
-// Stop shutdown iterator.
+// Stop shutdown iterator, the connection pool is shared and stays open.
 func (i *cdcIterator) Stop() error {
 	// send signal for finish clear tracking table.
 	i.tableSrv.stopCh <- struct{}{}
@@ -231,18 +249,10 @@ func (i *cdcIterator) Stop() error {
 	select {
 	// wait until clearing tracking table will be finished.
 	case <-i.tableSrv.canCloseCh:
-		if i.db != nil {
-			i.tableSrv.close()
-
-			return i.db.Close()
-		}
+		i.tableSrv.close()
 	// waiting timeout.
 	case <-time.After(waitingTimeoutSec * time.Second):
-		if i.db != nil {
-			i.tableSrv.close()
-
-			return i.db.Close()
-		}
+		i.tableSrv.close()
 	}
 
 	return nil
@@ -274,26 +284,24 @@ func (i *cdcIterator) Ack(_ context.Context, pos *position.Position) error {
 func (i *cdcIterator) loadRows(ctx context.Context) error {
 	selectBuilder := sqlbuilder.NewSelectBuilder()
 
-	if len(i.columns) > 0 {
-		// append additional columns
-		selectBuilder.Select(append(i.columns,
-			[]string{columnTrackingID, columnOperationType, columnTimeCreated}...)...)
-	} else {
-		selectBuilder.Select("*")
-	}
+	// append additional columns
+	selectBuilder.Select(append(i.tableInfo.SelectColumns(i.columns, i.transformOpts.LOBPolicies),
+		common.QuoteIdentifiers([]string{columnTrackingID, columnOperationType, columnTimeCreated})...)...)
 
-	selectBuilder.From(i.trackingTable)
+	selectBuilder.From(common.QuoteIdentifier(i.trackingTable))
 
 	if i.position != nil {
 		selectBuilder.Where(
-			selectBuilder.GreaterThan(columnTrackingID, i.position.CDCLastID),
+			selectBuilder.GreaterThan(common.QuoteIdentifier(columnTrackingID), i.position.CDCLastID),
 		)
 	}
 
-	q, args := selectBuilder.
-		OrderBy(columnTrackingID).
-		Limit(i.batchSize).
-		Build()
+	q, args := i.dialect.BuildSelect(selectBuilder.OrderBy(common.QuoteIdentifier(columnTrackingID)),
+		common.SelectOptions{
+			Limit:     i.batchSize,
+			ReadOnly:  true,
+			Isolation: i.isolation,
+		})
 
 	rows, err := i.db.QueryxContext(ctx, q, args...)
 	if err != nil {
@@ -324,8 +332,8 @@ func (i *cdcIterator) deleteRows(ctx context.Context) error {
 	deleteBuilder := sqlbuilder.NewDeleteBuilder()
 
 	q, args := deleteBuilder.
-		DeleteFrom(i.trackingTable).
-		Where(deleteBuilder.In(columnTrackingID, i.tableSrv.idsForRemoving...)).
+		DeleteFrom(common.QuoteIdentifier(i.trackingTable)).
+		Where(deleteBuilder.In(common.QuoteIdentifier(columnTrackingID), i.tableSrv.idsForRemoving...)).
 		Build()
 
 	_, err = tx.ExecContext(ctx, q, args...)
@@ -353,7 +361,7 @@ func (i *cdcIterator) clearTrackingTable(ctx context.Context) {
 				i.tableSrv.errCh <- err
 			}
 
-			// clearing was finished, db can be closed.
+			// clearing was finished, the pool can be closed.
 			i.tableSrv.canCloseCh <- struct{}{}
 
 			return
@@ -370,10 +378,11 @@ func (i *cdcIterator) clearTrackingTable(ctx context.Context) {
 }
 
 // setupCDC - create tracking table, add columns.
+// If the filter is not empty, the triggers only copy rows which satisfy it.
 func setupCDC(
 	ctx context.Context,
 	db *sqlx.DB,
-	tableName, trackingTableName, suffixName string,
+	tableName, trackingTableName, suffixName, filter string,
 	tableInfo coltypes.TableInfo,
 ) error {
 	var (
@@ -388,7 +397,7 @@ func setupCDC(
 	defer tx.Rollback() // nolint:errcheck,nolintlint
 
 	// check if table exist.
-	rows, err := tx.QueryContext(ctx, fmt.Sprintf(queryIfExistTable, trackingTableName))
+	rows, err := tx.QueryContext(ctx, queryIfExistTable, trackingTableName)
 	if err != nil {
 		return fmt.Errorf("query exist table: %w", err)
 	}
@@ -412,14 +421,18 @@ func setupCDC(
 
 	if !trackingTableExist {
 		// create tracking table
-		_, err = tx.ExecContext(ctx, fmt.Sprintf(queryCreateTable, trackingTableName, tableInfo.GetCreateColumnStr(),
-			columnOperationType, columnTimeCreated, columnTrackingID))
+		_, err = tx.ExecContext(ctx, fmt.Sprintf(queryCreateTable, common.QuoteIdentifier(trackingTableName),
+			tableInfo.GetCreateColumnStr(), common.QuoteIdentifier(columnOperationType),
+			common.QuoteIdentifier(columnTimeCreated), common.QuoteIdentifier(columnTrackingID)))
 		if err != nil {
 			return fmt.Errorf("create tracking table: %w", err)
 		}
 	}
 
-	triggersQuery := buildTriggers(trackingTableName, tableName, suffixName, tableInfo.ColumnTypes)
+	triggersQuery, err := buildTriggers(trackingTableName, tableName, suffixName, filter, tableInfo.ColumnTypes)
+	if err != nil {
+		return fmt.Errorf("build triggers: %w", err)
+	}
 
 	// add trigger to catch insert.
 	_, err = tx.ExecContext(ctx, triggersQuery.queryTriggerCatchInsert)
@@ -19,6 +19,8 @@ type actionType string
 const (
 	// metadata related.
 	metadataTable = "db2.table"
+	// metadataSnapshotCompleted marks the last record of a snapshot.
+	metadataSnapshotCompleted = "db2.snapshot.completed"
 
 	ActionInsert actionType = "INSERT"
 	ActionUpdate actionType = "UPDATE"
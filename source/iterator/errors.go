@@ -25,4 +25,6 @@ var (
 	ErrWrongTrackingOperatorType = errors.New("tracking column wrong type")
 	ErrNoInitializedIterator     = errors.New("not initialized iterator")
 	ErrUnknownOperatorType       = errors.New("unknown iterator type")
+	ErrCDCPositionInSnapshotMode = errors.New("CDC position can't be resumed in the snapshot mode")
+	ErrUnknownMode               = errors.New("unknown mode")
 )
@@ -20,8 +20,10 @@ import (
 	"time"
 
 	"github.com/conduitio-labs/conduit-connector-db2/coltypes"
+	"github.com/conduitio-labs/conduit-connector-db2/common"
 	"github.com/conduitio-labs/conduit-connector-db2/source/position"
 	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -39,8 +41,8 @@ type CombinedIterator struct {
 	cdc      *cdcIterator
 	snapshot *snapshotIterator
 
-	// connection string.
-	conn string
+	// db connection pool shared by the iterators.
+	db *sqlx.DB
 
 	// table - table name.
 	table string
@@ -57,19 +59,39 @@ type CombinedIterator struct {
 	batchSize int
 	// info about table
 	tableInfo coltypes.TableInfo
+	// transformOpts options for converting row values.
+	transformOpts coltypes.TransformOptions
+	// dialect SQL dialect of the DB2 platform.
+	dialect common.Dialect
+	// isolation isolation level of the queries.
+	isolation common.Isolation
+	// filter predicate which read rows must satisfy.
+	filter string
+	// query select query which is read instead of the table, only a snapshot of it is taken.
+	query string
+	// mode mode of reading the table.
+	mode Mode
 }
 
 // CombinedParams is an incoming params for the [NewCombinedIterator] function.
 type CombinedParams struct {
 	DB             *sqlx.DB
-	Conn           string
 	Table          string
 	OrderingColumn string
 	CfgKeys        []string
 	Columns        []string
 	BatchSize      int
-	Snapshot       bool
+	Mode           Mode
+	DecimalFormat  coltypes.DecimalFormat
+	TimeFormat     coltypes.TimeFormat
+	Location       *time.Location
+	LOBPolicies    coltypes.LOBPolicies
 	SdkPosition    opencdc.Position
+	Dialect        common.Dialect
+	Isolation      common.Isolation
+	Filter         string
+	FilterTriggers bool
+	Query          string
 }
 
 // NewCombinedIterator - create new iterator.
@@ -81,42 +103,77 @@ func NewCombinedIterator(ctx context.Context, params CombinedParams) (*CombinedI
 		return nil, fmt.Errorf("parse position: %w", err)
 	}
 
-	suffixName := getSuffixName(pos)
+	pos, err = migratePosition(ctx, pos, params.Mode)
+	if err != nil {
+		return nil, fmt.Errorf("migrate position: %w", err)
+	}
 
 	it := &CombinedIterator{
-		conn:           params.Conn,
+		db:             params.DB,
 		table:          params.Table,
 		columns:        params.Columns,
 		orderingColumn: params.OrderingColumn,
 		batchSize:      params.BatchSize,
-		trackingTable:  fmt.Sprintf(trackingTablePattern, params.Table, suffixName),
+		transformOpts: coltypes.TransformOptions{
+			DecimalFormat: params.DecimalFormat,
+			TimeFormat:    params.TimeFormat,
+			Location:      params.Location,
+			LOBPolicies:   params.LOBPolicies,
+		},
+		dialect:   params.Dialect,
+		isolation: params.Isolation,
+		filter:    params.Filter,
+		query:     params.Query,
+		mode:      params.Mode,
 	}
 
 	// get column types for converting and get primary keys information
-	it.tableInfo, err = coltypes.GetTableInfo(ctx, params.DB, params.Table)
+	if it.query != "" {
+		it.tableInfo, err = it.queryInfo(ctx)
+	} else {
+		it.tableInfo, err = coltypes.GetTableInfo(ctx, params.DB, params.Table)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("get table info: %w", err)
 	}
 
 	it.setKeys(params.CfgKeys)
 
-	// create tracking table, create triggers for cdc logic.
-	err = setupCDC(ctx, params.DB, it.table, it.trackingTable, suffixName, it.tableInfo)
-	if err != nil {
-		return nil, fmt.Errorf("setup cdc: %w", err)
+	// the suffix identifies the snapshot in its positions, the snapshot mode doesn't create
+	// the tracking table and triggers.
+	suffixName := getSuffixName(pos)
+	if it.mode != ModeSnapshot {
+		it.trackingTable = fmt.Sprintf(trackingTablePattern, params.Table, suffixName)
+
+		var triggerFilter string
+		if params.FilterTriggers {
+			triggerFilter = params.Filter
+		}
+
+		// create tracking table, create triggers for cdc logic.
+		err = setupCDC(ctx, params.DB, it.table, it.trackingTable, suffixName, triggerFilter, it.tableInfo)
+		if err != nil {
+			return nil, fmt.Errorf("setup cdc: %w", err)
+		}
 	}
 
-	if params.Snapshot && (pos == nil || pos.IteratorType == position.TypeSnapshot) {
+	if it.mode != ModeCDC && (pos == nil || pos.IteratorType == position.TypeSnapshot) {
 		it.snapshot, err = newSnapshotIterator(ctx, snapshotParams{
 			db:             params.DB,
 			table:          params.Table,
+			query:          it.query,
 			orderingColumn: params.OrderingColumn,
 			keys:           it.keys,
 			columns:        params.Columns,
 			batchSize:      params.BatchSize,
 			position:       pos,
-			columnTypes:    it.tableInfo.ColumnTypes,
+			tableInfo:      it.tableInfo,
+			transformOpts:  it.transformOpts,
 			suffixName:     suffixName,
+			mode:           it.mode,
+			dialect:        it.dialect,
+			isolation:      it.isolation,
+			filter:         it.filter,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("new shapshot iterator: %w", err)
@@ -129,8 +186,12 @@ func NewCombinedIterator(ctx context.Context, params CombinedParams) (*CombinedI
 			keys:          it.keys,
 			columns:       it.columns,
 			batchSize:     it.batchSize,
-			columnTypes:   it.tableInfo.ColumnTypes,
+			tableInfo:     it.tableInfo,
+			transformOpts: it.transformOpts,
 			position:      pos,
+			mode:          it.mode,
+			dialect:       it.dialect,
+			isolation:     it.isolation,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("new shapshot iterator: %w", err)
@@ -142,6 +203,8 @@ func NewCombinedIterator(ctx context.Context, params CombinedParams) (*CombinedI
 
 // HasNext returns a bool indicating whether the iterator has the next record to return or not.
 // If the underlying snapshot iterator returns false, the combined iterator will try to switch to the cdc iterator.
+// The switch is resumable: if the cdc iterator can't be created, e.g. because of a transient error,
+// the next call creates it again.
 func (c *CombinedIterator) HasNext(ctx context.Context) (bool, error) {
 	switch {
 	case c.snapshot != nil:
@@ -150,12 +213,20 @@ func (c *CombinedIterator) HasNext(ctx context.Context) (bool, error) {
 			return false, fmt.Errorf("snapshot has next: %w", err)
 		}
 
-		if !hasNext {
-			if er := c.switchToCDCIterator(ctx); er != nil {
-				return false, fmt.Errorf("switch to cdc iterator: %w", er)
+		if !hasNext && c.mode == ModeSnapshot {
+			if er := c.snapshot.Stop(); er != nil {
+				return false, fmt.Errorf("stop snapshot iterator: %w", er)
 			}
 
-			return c.cdc.HasNext(ctx)
+			c.snapshot = nil
+
+			sdk.Logger(ctx).Info().Str("table", c.table).Msg("snapshot completed, no more records will be read")
+
+			return false, nil
+		}
+
+		if !hasNext {
+			return c.switchToCDCIterator(ctx)
 		}
 
 		return true, nil
@@ -163,6 +234,10 @@ func (c *CombinedIterator) HasNext(ctx context.Context) (bool, error) {
 	case c.cdc != nil:
 		return c.cdc.HasNext(ctx)
 
+	case c.mode != ModeSnapshot:
+		// the snapshot ended, but the cdc iterator wasn't created.
+		return c.switchToCDCIterator(ctx)
+
 	default:
 		return false, nil
 	}
@@ -209,36 +284,52 @@ func (c *CombinedIterator) Ack(ctx context.Context, rp opencdc.Position) error {
 	return nil
 }
 
-func (c *CombinedIterator) switchToCDCIterator(ctx context.Context) error {
-	var err error
-
-	err = c.snapshot.Stop()
-	if err != nil {
-		return fmt.Errorf("stop snaphot iterator: %w", err)
-	}
-
-	c.snapshot = nil
+// switchToCDCIterator stops the snapshot iterator and creates the cdc iterator, and returns whether
+// the cdc iterator has the next record. The snapshot iterator is stopped once, when it is still set.
+func (c *CombinedIterator) switchToCDCIterator(ctx context.Context) (bool, error) {
+	if c.snapshot != nil {
+		if err := c.snapshot.Stop(); err != nil {
+			return false, fmt.Errorf("stop snaphot iterator: %w", err)
+		}
 
-	db, err := sqlx.Open("go_ibm_db", c.conn)
-	if err != nil {
-		return err
+		c.snapshot = nil
 	}
 
-	c.cdc, err = newCDCIterator(ctx, cdcParams{
-		db:            db,
+	cdc, err := newCDCIterator(ctx, cdcParams{
+		db:            c.db,
 		table:         c.table,
 		trackingTable: c.trackingTable,
 		keys:          c.keys,
 		columns:       c.columns,
 		batchSize:     c.batchSize,
-		columnTypes:   c.tableInfo.ColumnTypes,
+		tableInfo:     c.tableInfo,
+		transformOpts: c.transformOpts,
 		position:      nil,
+		mode:          c.mode,
+		dialect:       c.dialect,
+		isolation:     c.isolation,
 	})
 	if err != nil {
-		return fmt.Errorf("new cdc iterator: %w", err)
+		return false, fmt.Errorf("switch to cdc iterator: new cdc iterator: %w", err)
 	}
 
-	return nil
+	c.cdc = cdc
+
+	return c.cdc.HasNext(ctx)
+}
+
+// queryInfo returns information about the result columns of the custom query, described by the database.
+func (c *CombinedIterator) queryInfo(ctx context.Context) (coltypes.TableInfo, error) {
+	tableInfo, err := coltypes.DescribeQuery(ctx, c.db, c.query)
+	if err != nil {
+		return coltypes.TableInfo{}, fmt.Errorf("describe query: %w", err)
+	}
+
+	if _, ok := tableInfo.ColumnTypes[c.orderingColumn]; !ok {
+		return coltypes.TableInfo{}, fmt.Errorf("column %q of the query: %w", c.orderingColumn, ErrNoOrderingColumn)
+	}
+
+	return tableInfo, nil
 }
 
 func (c *CombinedIterator) setKeys(cfgKeys []string) {
@@ -260,7 +351,7 @@ func (c *CombinedIterator) setKeys(cfgKeys []string) {
 
 func getSuffixName(pos *position.Position) string {
 	// get suffix from position
-	if pos != nil {
+	if pos != nil && pos.SuffixName != "" {
 		return pos.SuffixName
 	}
 
@@ -0,0 +1,141 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/conduitio-labs/conduit-connector-db2/coltypes"
+	"github.com/conduitio-labs/conduit-connector-db2/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/matryer/is"
+)
+
+var errTransient = errors.New("SQL30081N communication error")
+
+// testConnector connects to a database whose queries return no rows, except the failing query,
+// which fails with a transient error.
+type testConnector struct {
+	// failingQuery is the number of the failing query, counted from 1.
+	failingQuery int
+	// queries is the number of executed queries.
+	queries int
+}
+
+func (c *testConnector) Connect(context.Context) (driver.Conn, error) {
+	return testConn{connector: c}, nil
+}
+
+func (c *testConnector) Driver() driver.Driver {
+	return testDriver{connector: c}
+}
+
+type testDriver struct {
+	connector *testConnector
+}
+
+func (d testDriver) Open(string) (driver.Conn, error) {
+	return testConn(d), nil
+}
+
+type testConn struct {
+	connector *testConnector
+}
+
+func (testConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.ErrUnsupported
+}
+
+func (testConn) Close() error {
+	return nil
+}
+
+func (testConn) Begin() (driver.Tx, error) {
+	return nil, errors.ErrUnsupported
+}
+
+func (c testConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	c.connector.queries++
+	if c.connector.queries == c.connector.failingQuery {
+		return nil, errTransient
+	}
+
+	return emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string {
+	return []string{}
+}
+
+func (emptyRows) Close() error {
+	return nil
+}
+
+func (emptyRows) Next([]driver.Value) error {
+	return io.EOF
+}
+
+func TestCombinedIterator_HasNext_switchToCDC(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	// the first query loads the last batch of the snapshot, the second one the first batch of the cdc.
+	db := sqlx.NewDb(sql.OpenDB(&testConnector{failingQuery: 2}), "db2")
+	defer db.Close()
+
+	tableInfo := coltypes.TableInfo{ColumnTypes: map[string]string{"ID": "INTEGER"}}
+
+	it := &CombinedIterator{
+		snapshot: &snapshotIterator{
+			db:             db,
+			table:          "USERS",
+			orderingColumn: "ID",
+			batchSize:      10,
+			tableInfo:      tableInfo,
+			dialect:        common.NewDialect(common.PlatformLUW),
+		},
+		db:             db,
+		table:          "USERS",
+		trackingTable:  "CONDUIT_USERS_150405",
+		keys:           []string{"ID"},
+		orderingColumn: "ID",
+		batchSize:      10,
+		tableInfo:      tableInfo,
+		dialect:        common.NewDialect(common.PlatformLUW),
+		mode:           ModeSnapshotCDC,
+	}
+
+	_, err := it.HasNext(ctx)
+	is.True(errors.Is(err, errTransient))
+	is.True(it.cdc == nil)
+
+	// the retried call creates the cdc iterator.
+	hasNext, err := it.HasNext(ctx)
+	is.NoErr(err)
+	is.True(!hasNext)
+	is.True(it.snapshot == nil)
+	is.True(it.cdc != nil)
+
+	is.NoErr(it.Stop())
+}
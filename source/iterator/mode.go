@@ -0,0 +1,86 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conduitio-labs/conduit-connector-db2/source/position"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// Mode is a mode of reading the table.
+type Mode string
+
+const (
+	// ModeSnapshot reads the table once, without creating the tracking table and triggers.
+	ModeSnapshot Mode = "snapshot"
+	// ModeCDC skips the snapshot and only reads changes of the table.
+	ModeCDC Mode = "cdc"
+	// ModeSnapshotCDC reads the table and then its changes.
+	ModeSnapshotCDC Mode = "snapshot+cdc"
+)
+
+// migratePosition returns the position the iterator of the mode resumes from.
+// Positions without a mode were created before the source had modes, when the tracking table and triggers
+// were always created, so they are migrated as positions of the snapshot+cdc mode.
+// The suffix of positions of the snapshot mode identifies the snapshot, it doesn't have a tracking table.
+func migratePosition(ctx context.Context, pos *position.Position, mode Mode) (*position.Position, error) {
+	if pos == nil {
+		return nil, nil
+	}
+
+	if pos.Mode == "" {
+		pos.Mode = string(ModeSnapshotCDC)
+	}
+
+	switch mode {
+	case ModeSnapshot:
+		if pos.IteratorType != position.TypeSnapshot {
+			return nil, ErrCDCPositionInSnapshotMode
+		}
+
+		if pos.Mode != string(ModeSnapshot) && pos.SuffixName != "" {
+			sdk.Logger(ctx).Warn().
+				Str("suffix", pos.SuffixName).
+				Msgf("the tracking table and triggers with the suffix of the position are not used in the %s mode, "+
+					"they can be dropped", mode)
+		}
+
+	case ModeCDC:
+		if pos.IteratorType != position.TypeSnapshot {
+			break
+		}
+
+		// the snapshot is skipped, changes are read from the tracking table of the position if it has one.
+		if pos.Mode == string(ModeSnapshot) || pos.SuffixName == "" {
+			return nil, nil
+		}
+
+		pos = &position.Position{
+			IteratorType: position.TypeCDC,
+			SuffixName:   pos.SuffixName,
+		}
+
+	case ModeSnapshotCDC:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMode, mode)
+	}
+
+	pos.Mode = string(mode)
+
+	return pos, nil
+}
@@ -0,0 +1,133 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/conduitio-labs/conduit-connector-db2/source/position"
+	"github.com/matryer/is"
+)
+
+func TestMigratePosition(t *testing.T) {
+	t.Parallel()
+
+	// legacySnapshot returns a snapshot position created before the source had modes.
+	legacySnapshot := func() *position.Position {
+		return &position.Position{
+			IteratorType:             position.TypeSnapshot,
+			SnapshotLastProcessedVal: int64(2),
+			SuffixName:               "150405",
+		}
+	}
+
+	// snapshot returns a position of the snapshot mode, which suffix identifies the snapshot.
+	snapshot := func() *position.Position {
+		return &position.Position{
+			IteratorType:             position.TypeSnapshot,
+			Mode:                     "snapshot",
+			SnapshotLastProcessedVal: int64(2),
+			SuffixName:               "101010",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		pos     *position.Position
+		mode    Mode
+		want    *position.Position
+		wantErr error
+	}{
+		{
+			name: "no position",
+			pos:  nil,
+			mode: ModeSnapshot,
+			want: nil,
+		},
+		{
+			name: "legacy snapshot position in snapshot+cdc mode",
+			pos:  legacySnapshot(),
+			mode: ModeSnapshotCDC,
+			want: &position.Position{
+				IteratorType: position.TypeSnapshot, Mode: "snapshot+cdc", SnapshotLastProcessedVal: int64(2),
+				SuffixName: "150405",
+			},
+		},
+		{
+			name: "legacy snapshot position in snapshot mode",
+			pos:  legacySnapshot(),
+			mode: ModeSnapshot,
+			want: &position.Position{
+				IteratorType: position.TypeSnapshot, Mode: "snapshot", SnapshotLastProcessedVal: int64(2),
+				SuffixName: "150405",
+			},
+		},
+		{
+			name: "snapshot mode position in snapshot mode",
+			pos:  snapshot(),
+			mode: ModeSnapshot,
+			want: snapshot(),
+		},
+		{
+			name:    "legacy cdc position in snapshot mode",
+			pos:     &position.Position{IteratorType: position.TypeCDC, CDCLastID: 7, SuffixName: "150405"},
+			mode:    ModeSnapshot,
+			wantErr: ErrCDCPositionInSnapshotMode,
+		},
+		{
+			name: "legacy snapshot position in cdc mode",
+			pos:  legacySnapshot(),
+			mode: ModeCDC,
+			want: &position.Position{IteratorType: position.TypeCDC, Mode: "cdc", SuffixName: "150405"},
+		},
+		{
+			name: "snapshot mode position in cdc mode",
+			pos:  snapshot(),
+			mode: ModeCDC,
+			want: nil,
+		},
+		{
+			name: "legacy cdc position in cdc mode",
+			pos:  &position.Position{IteratorType: position.TypeCDC, CDCLastID: 7, SuffixName: "150405"},
+			mode: ModeCDC,
+			want: &position.Position{IteratorType: position.TypeCDC, Mode: "cdc", CDCLastID: 7, SuffixName: "150405"},
+		},
+		{
+			name:    "unknown mode",
+			pos:     &position.Position{IteratorType: position.TypeCDC},
+			mode:    "full",
+			wantErr: ErrUnknownMode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			got, err := migratePosition(context.Background(), tt.pos, tt.mode)
+			if tt.wantErr != nil {
+				is.True(errors.Is(err, tt.wantErr))
+
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
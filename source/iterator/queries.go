@@ -16,12 +16,15 @@ package iterator
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+
+	"github.com/conduitio-labs/conduit-connector-db2/common"
 )
 
 const (
 	queryIfExistTable = `
-	SELECT count(*) AS count FROM  SysCat.Tables WHERE TabName='%s'
+	SELECT count(*) AS count FROM  SysCat.Tables WHERE TabName = ?
 `
 	queryCreateTable = `
 		CREATE TABLE %s (
@@ -32,20 +35,29 @@ const (
 		)
 	`
 	queryTriggerTemplate = `
-      CREATE OR REPLACE TRIGGER CD_{{table}}_{{operation_type}}_%s
-      AFTER {{operation_type}} ON {{table}}
-      REFERENCING {{row_type}} ROW AS rw
+      CREATE OR REPLACE TRIGGER %s
+      AFTER %s ON %s
+      REFERENCING %s
       FOR EACH ROW
+      %s
       BEGIN ATOMIC
-        INSERT INTO %s (%s) VALUES (%s,'{{operation_type}}');
+        INSERT INTO %s (%s) VALUES (%s,%s);
       END
 	`
 
 	queryGetMaxValue = `SELECT max(%s) FROM %s`
 
-	placeholderOperationType = "{{operation_type}}"
-	placeholderTable         = "{{table}}"
-	placeholderRowType       = "{{row_type}}"
+	// querySubselectName is a correlation name of the custom query in the snapshot queries.
+	querySubselectName = "CONDUIT_QUERY"
+
+	// triggerNamePattern is a pattern of trigger names: table, operation type and suffix.
+	triggerNamePattern = "CD_%s_%s_%s"
+
+	// rowCorrelation is a correlation name of the row copied by the triggers, the new row of inserts and updates
+	// and the old row of deletes.
+	rowCorrelation = "rw"
+	// oldRowCorrelation is a correlation name of the old row of updates.
+	oldRowCorrelation = "orw"
 )
 
 type queryTriggers struct {
@@ -54,37 +66,93 @@ type queryTriggers struct {
 	queryTriggerCatchDelete string
 }
 
-func buildTriggers(trackingTable, table, suffix string, columnsTypes map[string]string) queryTriggers {
-	columnNames := make([]string, 0)
+// buildTriggers returns queries creating triggers which copy changed rows of the table to the tracking table.
+// If the filter is set, only changes of the rows which match it are copied. An update of a row which enters
+// the filter is copied as an insert, and an update of a row which leaves it is copied as a delete, so
+// the rows read from the tracking table remain the rows which match the filter.
+func buildTriggers(
+	trackingTable, table, suffix, filter string,
+	columnsTypes map[string]string,
+) (queryTriggers, error) {
+	columnNames := sortedColumns(columnsTypes)
+
+	condition, err := triggerCondition(filter, rowCorrelation, columnsTypes)
+	if err != nil {
+		return queryTriggers{}, err
+	}
 
-	for key := range columnsTypes {
-		columnNames = append(columnNames, key)
+	oldCondition, err := triggerCondition(filter, oldRowCorrelation, columnsTypes)
+	if err != nil {
+		return queryTriggers{}, err
 	}
 
 	nwValues := make([]string, len(columnNames))
 	for i := range columnNames {
-		nwValues[i] = fmt.Sprintf("rw.%s", columnNames[i])
+		nwValues[i] = rowCorrelation + "." + common.QuoteIdentifier(columnNames[i])
 	}
 
-	columnNames = append(columnNames, columnOperationType)
+	columns := strings.Join(common.QuoteIdentifiers(append(columnNames, columnOperationType)), ",")
+
+	buildTrigger := func(operationType actionType, referencing, when, operation string) string {
+		name := fmt.Sprintf(triggerNamePattern, table, operationType, suffix)
+
+		return fmt.Sprintf(queryTriggerTemplate, common.QuoteIdentifier(name), operationType,
+			common.QuoteIdentifier(table), referencing, when, common.QuoteIdentifier(trackingTable), columns,
+			strings.Join(nwValues, ","), operation)
+	}
+
+	var (
+		when            string
+		updateWhen      string
+		updateOperation = operationLiteral(ActionUpdate)
+		newRow          = "NEW ROW AS " + rowCorrelation
+		oldRow          = "OLD ROW AS " + rowCorrelation
+		newAndOldRows   = newRow + " OLD ROW AS " + oldRowCorrelation
+	)
+
+	if condition != "" {
+		when = "WHEN (" + condition + ")"
+		updateWhen = fmt.Sprintf("WHEN ((%s) OR (%s))", condition, oldCondition)
+		updateOperation = fmt.Sprintf("CASE WHEN (%s) AND (%s) THEN %s WHEN (%s) THEN %s ELSE %s END",
+			condition, oldCondition, operationLiteral(ActionUpdate), condition, operationLiteral(ActionInsert),
+			operationLiteral(ActionDelete))
+	}
 
-	triggerTemplate := fmt.Sprintf(queryTriggerTemplate, suffix, trackingTable,
-		strings.Join(columnNames, ","), strings.Join(nwValues, ","))
+	return queryTriggers{
+		queryTriggerCatchInsert: buildTrigger(ActionInsert, newRow, when, operationLiteral(ActionInsert)),
+		queryTriggerCatchUpdate: buildTrigger(ActionUpdate, newAndOldRows, updateWhen, updateOperation),
+		queryTriggerCatchDelete: buildTrigger(ActionDelete, oldRow, when, operationLiteral(ActionDelete)),
+	}, nil
+}
 
-	triggerTemplate = strings.ReplaceAll(triggerTemplate, placeholderTable, table)
+// operationLiteral returns the operation type as an SQL string literal.
+func operationLiteral(operationType actionType) string {
+	return "'" + string(operationType) + "'"
+}
 
-	queryTriggerInsert := strings.ReplaceAll(triggerTemplate, placeholderOperationType, string(ActionInsert))
-	queryTriggerInsert = strings.ReplaceAll(queryTriggerInsert, placeholderRowType, "NEW")
+// triggerCondition compiles the filter into the condition of the triggers' WHEN clause,
+// which references the columns of a row by the correlation name.
+func triggerCondition(filter, correlation string, columnsTypes map[string]string) (string, error) {
+	if filter == "" {
+		return "", nil
+	}
 
-	queryTriggerUpdate := strings.ReplaceAll(triggerTemplate, placeholderOperationType, string(ActionUpdate))
-	queryTriggerUpdate = strings.ReplaceAll(queryTriggerUpdate, placeholderRowType, "NEW")
+	condition, err := common.QualifyColumns(filter, correlation, sortedColumns(columnsTypes))
+	if err != nil {
+		return "", fmt.Errorf("qualify columns: %w", err)
+	}
 
-	queryTriggerDelete := strings.ReplaceAll(triggerTemplate, placeholderOperationType, string(ActionDelete))
-	queryTriggerDelete = strings.ReplaceAll(queryTriggerDelete, placeholderRowType, "OLD")
+	return condition, nil
+}
 
-	return queryTriggers{
-		queryTriggerCatchInsert: queryTriggerInsert,
-		queryTriggerCatchUpdate: queryTriggerUpdate,
-		queryTriggerCatchDelete: queryTriggerDelete,
+// sortedColumns returns the sorted column names.
+func sortedColumns(columnsTypes map[string]string) []string {
+	columnNames := make([]string, 0, len(columnsTypes))
+	for key := range columnsTypes {
+		columnNames = append(columnNames, key)
 	}
+
+	sort.Strings(columnNames)
+
+	return columnNames
 }
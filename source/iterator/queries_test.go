@@ -0,0 +1,115 @@
+// Copyright © 2022 Meroxa, Inc & Yalantis.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iterator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/conduitio-labs/conduit-connector-db2/common"
+	"github.com/matryer/is"
+)
+
+func TestBuildTriggers(t *testing.T) {
+	t.Parallel()
+	is := is.New(t)
+
+	triggers, err := buildTriggers("CONDUIT_Users_150405", "Users", "150405", "",
+		map[string]string{"NAME": "VARCHAR", "Id": "INTEGER"})
+	is.NoErr(err)
+
+	normalize := func(query string) string {
+		return strings.Join(strings.Fields(query), " ")
+	}
+
+	is.Equal(normalize(triggers.queryTriggerCatchInsert),
+		`CREATE OR REPLACE TRIGGER "CD_Users_INSERT_150405" AFTER INSERT ON "Users" `+
+			`REFERENCING NEW ROW AS rw FOR EACH ROW BEGIN ATOMIC `+
+			`INSERT INTO "CONDUIT_Users_150405" ("Id","NAME","CONDUIT_OPERATION_TYPE") `+
+			`VALUES (rw."Id",rw."NAME",'INSERT'); END`)
+	is.True(strings.Contains(normalize(triggers.queryTriggerCatchUpdate),
+		`AFTER UPDATE ON "Users" REFERENCING NEW ROW AS rw OLD ROW AS orw FOR EACH ROW BEGIN ATOMIC`))
+	is.True(strings.Contains(triggers.queryTriggerCatchUpdate, `VALUES (rw."Id",rw."NAME",'UPDATE')`))
+	is.True(strings.Contains(triggers.queryTriggerCatchDelete, "REFERENCING OLD ROW AS rw"))
+
+	// quotes in names are escaped, so they can't end the delimited identifier.
+	triggers, err = buildTriggers("CONDUIT_T_1", `T"; DROP TABLE X; --`, "1", "", map[string]string{"ID": "INTEGER"})
+	is.NoErr(err)
+	is.True(strings.Contains(triggers.queryTriggerCatchInsert, `AFTER INSERT ON "T""; DROP TABLE X; --"`))
+
+	// the filter is added as the WHEN clause.
+	triggers, err = buildTriggers("CONDUIT_T_1", "T", "1", "id > 10", map[string]string{"ID": "INTEGER"})
+	is.NoErr(err)
+	is.True(strings.Contains(normalize(triggers.queryTriggerCatchInsert),
+		`REFERENCING NEW ROW AS rw FOR EACH ROW WHEN (rw."ID" > 10) BEGIN ATOMIC`))
+	is.True(strings.Contains(normalize(triggers.queryTriggerCatchDelete),
+		`REFERENCING OLD ROW AS rw FOR EACH ROW WHEN (rw."ID" > 10) BEGIN ATOMIC`))
+
+	// updates of rows which match the filter before or after the update are copied, a row updated into the filter
+	// is copied as an insert and a row updated out of it as a delete.
+	is.True(strings.Contains(normalize(triggers.queryTriggerCatchUpdate),
+		`FOR EACH ROW WHEN ((rw."ID" > 10) OR (orw."ID" > 10)) BEGIN ATOMIC`))
+	is.True(strings.Contains(normalize(triggers.queryTriggerCatchUpdate),
+		`VALUES (rw."ID",CASE WHEN (rw."ID" > 10) AND (orw."ID" > 10) THEN 'UPDATE' `+
+			`WHEN (rw."ID" > 10) THEN 'INSERT' ELSE 'DELETE' END);`))
+
+	_, err = buildTriggers("CONDUIT_T_1", "T", "1", "id > 10; DROP TABLE T", map[string]string{"ID": "INTEGER"})
+	is.True(errors.Is(err, common.ErrInvalidPredicate))
+}
+
+func TestTriggerCondition(t *testing.T) {
+	t.Parallel()
+
+	columns := map[string]string{"REGION": "VARCHAR", "Deleted": "SMALLINT", "ID": "INTEGER"}
+
+	tests := []struct {
+		name   string
+		filter string
+		want   string
+	}{
+		{
+			name:   "empty filter",
+			filter: "",
+			want:   "",
+		},
+		{
+			name:   "ordinary and delimited identifiers",
+			filter: `region = 'EU' AND "Deleted" = 0`,
+			want:   `rw."REGION" = 'EU' AND rw."Deleted" = 0`,
+		},
+		{
+			name:   "string literals, functions and special registers are kept",
+			filter: `UPPER(Region) <> 'id' AND ID < MAX(1, 2) AND CURRENT DATE > DATE('2024-01-01')`,
+			want:   `UPPER(rw."REGION") <> 'id' AND rw."ID" < MAX(1, 2) AND CURRENT DATE > DATE('2024-01-01')`,
+		},
+		{
+			name:   "qualified names are kept",
+			filter: `ID IN (SELECT T.ID FROM APP.T)`,
+			want:   `rw."ID" IN (SELECT T.ID FROM APP.T)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			is := is.New(t)
+
+			got, err := triggerCondition(tt.filter, "rw", columns)
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
@@ -18,9 +18,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/conduitio-labs/conduit-connector-db2/coltypes"
+	"github.com/conduitio-labs/conduit-connector-db2/common"
 	"github.com/conduitio-labs/conduit-connector-db2/source/position"
 	"github.com/conduitio/conduit-commons/opencdc"
 	sdk "github.com/conduitio/conduit-connector-sdk"
@@ -38,9 +40,13 @@ import (
 // Iterators saves last processed value from `orderingColumn` column to position to field `SnapshotLastProcessedVal`.
 // If snapshot stops it will parse position from last record and will
 // try gets row where `{{orderingColumn}} > {{position.SnapshotLastProcessedVal}}`.
+// The next row is fetched when a record is returned, so the last record of the snapshot is marked
+// with the snapshot completion metadata.
 type snapshotIterator struct {
 	db   *sqlx.DB
 	rows *sqlx.Rows
+	// fetched is true if the next row was fetched by Next.
+	fetched bool
 
 	// table - table name.
 	table string
@@ -57,10 +63,22 @@ type snapshotIterator struct {
 	batchSize int
 	// position last recorded position.
 	position *position.Position
-	// columnTypes column types from table.
-	columnTypes map[string]string
+	// tableInfo information about column types from table.
+	tableInfo coltypes.TableInfo
+	// transformOpts options for converting row values.
+	transformOpts coltypes.TransformOptions
 	// suffixName special suffix that connector uses for identify tracking table and triggers.
 	suffixName string
+	// mode mode of the source, which is saved to positions.
+	mode Mode
+	// dialect SQL dialect of the DB2 platform.
+	dialect common.Dialect
+	// isolation isolation level of the queries.
+	isolation common.Isolation
+	// filter predicate which read rows must satisfy.
+	filter string
+	// query select query which is read instead of the table.
+	query string
 }
 
 type snapshotParams struct {
@@ -71,8 +89,14 @@ type snapshotParams struct {
 	columns        []string
 	batchSize      int
 	position       *position.Position
-	columnTypes    map[string]string
+	tableInfo      coltypes.TableInfo
+	transformOpts  coltypes.TransformOptions
 	suffixName     string
+	mode           Mode
+	dialect        common.Dialect
+	isolation      common.Isolation
+	filter         string
+	query          string
 }
 
 func newSnapshotIterator(
@@ -89,8 +113,14 @@ func newSnapshotIterator(
 		orderingColumn: params.orderingColumn,
 		batchSize:      params.batchSize,
 		position:       params.position,
-		columnTypes:    params.columnTypes,
+		tableInfo:      params.tableInfo,
+		transformOpts:  params.transformOpts,
 		suffixName:     params.suffixName,
+		mode:           params.mode,
+		dialect:        params.dialect,
+		isolation:      params.isolation,
+		filter:         params.filter,
+		query:          params.query,
 	}
 
 	err = it.loadRows(ctx)
@@ -112,6 +142,10 @@ func newSnapshotIterator(
 
 // HasNext check ability to get next record.
 func (i *snapshotIterator) HasNext(ctx context.Context) (bool, error) {
+	if i.fetched {
+		return true, nil
+	}
+
 	if i.rows != nil && i.rows.Next() {
 		return true, nil
 	}
@@ -130,12 +164,14 @@ func (i *snapshotIterator) HasNext(ctx context.Context) (bool, error) {
 
 // Next get new record.
 func (i *snapshotIterator) Next(ctx context.Context) (opencdc.Record, error) {
+	i.fetched = false
+
 	row := make(map[string]any)
 	if err := i.rows.MapScan(row); err != nil {
 		return opencdc.Record{}, fmt.Errorf("scan rows: %w", err)
 	}
 
-	transformedRow, err := coltypes.TransformRow(ctx, row, i.columnTypes)
+	transformedRow, err := coltypes.TransformRow(ctx, row, i.tableInfo, i.transformOpts)
 	if err != nil {
 		return opencdc.Record{}, fmt.Errorf("transform row column types: %w", err)
 	}
@@ -146,7 +182,8 @@ func (i *snapshotIterator) Next(ctx context.Context) (opencdc.Record, error) {
 
 	pos := position.Position{
 		IteratorType:             position.TypeSnapshot,
-		SnapshotLastProcessedVal: transformedRow[i.orderingColumn],
+		Mode:                     string(i.mode),
+		SnapshotLastProcessedVal: positionValue(row[i.orderingColumn]),
 		SnapshotMaxValue:         i.maxValue,
 		SuffixName:               i.suffixName,
 	}
@@ -175,6 +212,10 @@ func (i *snapshotIterator) Next(ctx context.Context) (opencdc.Record, error) {
 	metadata := opencdc.Metadata(map[string]string{metadataTable: i.table})
 	metadata.SetCreatedAt(time.Now())
 
+	if i.fetchNext(ctx) {
+		metadata[metadataSnapshotCompleted] = "true"
+	}
+
 	return sdk.Util.Source.NewRecordSnapshot(
 			sdkPos,
 			metadata,
@@ -183,7 +224,37 @@ func (i *snapshotIterator) Next(ctx context.Context) (opencdc.Record, error) {
 		nil
 }
 
-// Stop shutdown iterator.
+// fetchNext fetches the next row after the returned record, loading the next batch if needed, and returns true
+// if the record is the last one of the snapshot. A failed load isn't an error of the returned record,
+// the record is not marked and HasNext loads the batch again.
+func (i *snapshotIterator) fetchNext(ctx context.Context) bool {
+	if i.rows.Next() {
+		i.fetched = true
+
+		return false
+	}
+
+	if err := i.loadRows(ctx); err != nil {
+		sdk.Logger(ctx).Warn().Err(err).Msg("failed to load the next batch of the snapshot")
+
+		i.rows = nil
+
+		return false
+	}
+
+	i.fetched = i.rows.Next()
+	if !i.fetched && i.rows.Err() != nil {
+		sdk.Logger(ctx).Warn().Err(i.rows.Err()).Msg("failed to fetch the next row of the snapshot")
+
+		i.rows = nil
+
+		return false
+	}
+
+	return !i.fetched
+}
+
+// Stop shutdown iterator, the connection pool is shared and stays open.
 func (i *snapshotIterator) Stop() error {
 	if i.rows != nil {
 		err := i.rows.Close()
@@ -192,10 +263,6 @@ func (i *snapshotIterator) Stop() error {
 		}
 	}
 
-	if i.db != nil {
-		return i.db.Close()
-	}
-
 	return nil
 }
 
@@ -204,25 +271,29 @@ func (i *snapshotIterator) Stop() error {
 func (i *snapshotIterator) loadRows(ctx context.Context) error {
 	builder := sqlbuilder.NewSelectBuilder()
 
-	if len(i.columns) > 0 {
-		builder.Select(i.columns...)
-	} else {
-		builder.Select("*")
-	}
+	builder.Select(i.tableInfo.SelectColumns(i.columns, i.transformOpts.LOBPolicies)...)
 
-	builder.From(i.table)
+	// the builder interpolates "$" in raw expressions, so it is escaped.
+	builder.From(strings.ReplaceAll(i.source(), "$", "$$"))
+
+	orderingColumn := common.QuoteIdentifier(i.orderingColumn)
+
+	if i.filter != "" {
+		builder.Where("(" + strings.ReplaceAll(i.filter, "$", "$$") + ")")
+	}
 
 	if i.position != nil {
 		builder.Where(
-			builder.GreaterThan(i.orderingColumn, i.position.SnapshotLastProcessedVal),
-			builder.LessEqualThan(i.orderingColumn, i.position.SnapshotMaxValue),
+			builder.GreaterThan(orderingColumn, i.position.SnapshotLastProcessedVal),
+			builder.LessEqualThan(orderingColumn, i.position.SnapshotMaxValue),
 		)
 	}
 
-	q, args := builder.
-		OrderBy(i.orderingColumn).
-		Limit(i.batchSize).
-		Build()
+	q, args := i.dialect.BuildSelect(builder.OrderBy(orderingColumn), common.SelectOptions{
+		Limit:     i.batchSize,
+		ReadOnly:  true,
+		Isolation: i.isolation,
+	})
 
 	rows, err := i.db.QueryxContext(ctx, q, args...)
 	if err != nil {
@@ -234,10 +305,27 @@ func (i *snapshotIterator) loadRows(ctx context.Context) error {
 	return nil
 }
 
+// source returns the table, or the query as a subselect, which the rows are selected from.
+func (i *snapshotIterator) source() string {
+	if i.query != "" {
+		return "(" + i.query + ") AS " + querySubselectName
+	}
+
+	return common.QuoteIdentifier(i.table)
+}
+
 // getMaxValue get max value from ordered column.
 func (i *snapshotIterator) setMaxValue(ctx context.Context) error {
+	query := fmt.Sprintf(queryGetMaxValue, common.QuoteIdentifier(i.orderingColumn), i.source())
+	if i.filter != "" {
+		query += " WHERE (" + i.filter + ")"
+	}
+
 	//nolint:sqlclosecheck // false positive https://github.com/ryanrolds/sqlclosecheck/issues/35
-	rows, err := i.db.QueryxContext(ctx, fmt.Sprintf(queryGetMaxValue, i.orderingColumn, i.table))
+	rows, err := i.db.QueryxContext(ctx, i.dialect.SelectClauses(query, common.SelectOptions{
+		ReadOnly:  true,
+		Isolation: i.isolation,
+	}))
 	if err != nil {
 		return fmt.Errorf("execute query get max value: %w", err)
 	}
@@ -251,7 +339,18 @@ func (i *snapshotIterator) setMaxValue(ctx context.Context) error {
 		}
 	}
 
-	i.maxValue = maxValue
+	i.maxValue = positionValue(maxValue)
 
 	return nil
 }
+
+// positionValue returns a value of the ordering column which can be stored in a position
+// and used as a query argument. Decimal and character values are returned by the driver
+// as byte slices, so they are stored as strings to keep them exact.
+func positionValue(value any) any {
+	if valueBytes, ok := value.([]byte); ok {
+		return string(valueBytes)
+	}
+
+	return value
+}
@@ -15,6 +15,8 @@
 package position
 
 import (
+	"bytes"
+	"cmp"
 	"encoding/json"
 	"fmt"
 
@@ -33,6 +35,9 @@ const (
 type Position struct {
 	// IteratorType - shows in what iterator was created position.
 	IteratorType IteratorType
+	// Mode - mode of the source the position was created in, it is empty in positions
+	// created before the source had modes.
+	Mode string `json:",omitempty"`
 
 	// Snapshot information.
 	// SnapshotLastProcessedVal - last processed value from ordering column.
@@ -55,11 +60,18 @@ func ParseSDKPosition(p opencdc.Position) (*Position, error) {
 		return nil, nil
 	}
 
-	err := json.Unmarshal(p, &pos)
+	// decode numbers as json.Number, so BIGINT and DECIMAL values are not rounded to float64.
+	decoder := json.NewDecoder(bytes.NewReader(p))
+	decoder.UseNumber()
+
+	err := decoder.Decode(&pos)
 	if err != nil {
 		return nil, fmt.Errorf("failed unmarshaling: %w", err)
 	}
 
+	pos.SnapshotLastProcessedVal = exactValue(pos.SnapshotLastProcessedVal)
+	pos.SnapshotMaxValue = exactValue(pos.SnapshotMaxValue)
+
 	switch pos.IteratorType {
 	case TypeSnapshot, TypeCDC:
 		return &pos, nil
@@ -72,3 +84,75 @@ func ParseSDKPosition(p opencdc.Position) (*Position, error) {
 func (p Position) ConvertToSDKPosition() (opencdc.Position, error) {
 	return json.Marshal(p)
 }
+
+// Compare compares positions of the source. Positions are only ordered within one run of an iterator:
+// snapshot positions of the same snapshot, with the same suffix and max value, are ordered by integer values
+// of the ordering column, and CDC positions of the same tracking table are ordered by the tracking ID.
+// Other positions can't be compared, e.g. positions of different snapshots, or character and decimal values,
+// whose order depends on the collation of the database, so false is returned for them.
+func Compare(a, b opencdc.Position) (int, bool) {
+	posA, posB, ok := parsePair(a, b)
+	if !ok || !sameRun(posA, posB) {
+		return 0, false
+	}
+
+	if posA.IteratorType == TypeCDC {
+		return cmp.Compare(posA.CDCLastID, posB.CDCLastID), true
+	}
+
+	valA, okA := posA.SnapshotLastProcessedVal.(int64)
+	valB, okB := posB.SnapshotLastProcessedVal.(int64)
+	if !okA || !okB {
+		return 0, false
+	}
+
+	return cmp.Compare(valA, valB), true
+}
+
+// DifferentRuns returns true if both positions are positions of the source which belong to different runs
+// of an iterator, e.g. positions of different snapshots, or a snapshot and a CDC position.
+// It returns false for positions of the same run, and if any of them is not a position of the source.
+func DifferentRuns(a, b opencdc.Position) bool {
+	posA, posB, ok := parsePair(a, b)
+
+	return ok && !sameRun(posA, posB)
+}
+
+// parsePair parses both positions, it returns false if any of them is not a position of the source.
+func parsePair(a, b opencdc.Position) (*Position, *Position, bool) {
+	posA, err := ParseSDKPosition(a)
+	if err != nil || posA == nil {
+		return nil, nil, false
+	}
+
+	posB, err := ParseSDKPosition(b)
+	if err != nil || posB == nil {
+		return nil, nil, false
+	}
+
+	return posA, posB, true
+}
+
+// sameRun returns true if the positions belong to the same snapshot, with the same suffix and max value,
+// or to the same tracking table.
+func sameRun(a, b *Position) bool {
+	if a.IteratorType != b.IteratorType || a.SuffixName == "" || a.SuffixName != b.SuffixName {
+		return false
+	}
+
+	return a.IteratorType == TypeCDC || a.SnapshotMaxValue == b.SnapshotMaxValue
+}
+
+// exactValue returns an int64 for integer numbers, and the exact string representation for other numbers.
+func exactValue(value any) any {
+	number, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+
+	if intValue, err := number.Int64(); err == nil {
+		return intValue
+	}
+
+	return number.String()
+}
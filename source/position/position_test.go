@@ -39,6 +39,10 @@ func TestParseSDKPosition(t *testing.T) {
 
 	snapshotPosBytes, _ := json.Marshal(snapshotPos)
 
+	// positions created before the source had modes don't have the Mode field.
+	legacyPos := opencdc.Position(`{"IteratorType":"c","SnapshotLastProcessedVal":null,` +
+		`"SnapshotMaxValue":null,"CDCLastID":7,"SuffixName":"150405"}`)
+
 	wrongPosBytes, _ := json.Marshal(wrongPosType)
 
 	tests := []struct {
@@ -53,6 +57,11 @@ func TestParseSDKPosition(t *testing.T) {
 			in:   opencdc.Position(snapshotPosBytes),
 			want: snapshotPos,
 		},
+		{
+			name: "legacy position",
+			in:   legacyPos,
+			want: Position{IteratorType: TypeCDC, CDCLastID: 7, SuffixName: "150405"},
+		},
 		{
 			name:        "unknown iterator type",
 			in:          opencdc.Position(wrongPosBytes),
@@ -82,3 +91,152 @@ func TestParseSDKPosition(t *testing.T) {
 		})
 	}
 }
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name   string
+		a      string
+		b      string
+		want   int
+		wantOK bool
+	}{
+		{
+			name:   "snapshot before",
+			a:      `{"IteratorType":"s","SnapshotLastProcessedVal":9,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+			b:      `{"IteratorType":"s","SnapshotLastProcessedVal":10,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+			want:   -1,
+			wantOK: true,
+		},
+		{
+			name:   "snapshot equal",
+			a:      `{"IteratorType":"s","SnapshotLastProcessedVal":10,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+			b:      `{"IteratorType":"s","SnapshotLastProcessedVal":10,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+			want:   0,
+			wantOK: true,
+		},
+		{
+			name:   "snapshot after",
+			a:      `{"IteratorType":"s","SnapshotLastProcessedVal":11,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+			b:      `{"IteratorType":"s","SnapshotLastProcessedVal":10,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+			want:   1,
+			wantOK: true,
+		},
+		{
+			name:   "cdc before",
+			a:      `{"IteratorType":"c","CDCLastID":5,"SuffixName":"213315"}`,
+			b:      `{"IteratorType":"c","CDCLastID":6,"SuffixName":"213315"}`,
+			want:   -1,
+			wantOK: true,
+		},
+		{
+			name:   "cdc after",
+			a:      `{"IteratorType":"c","CDCLastID":7,"SuffixName":"213315"}`,
+			b:      `{"IteratorType":"c","CDCLastID":6,"SuffixName":"213315"}`,
+			want:   1,
+			wantOK: true,
+		},
+		{
+			name: "snapshot of another suffix",
+			a:    `{"IteratorType":"s","SnapshotLastProcessedVal":9,"SnapshotMaxValue":20,"SuffixName":"100000"}`,
+			b:    `{"IteratorType":"s","SnapshotLastProcessedVal":10,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+		},
+		{
+			name: "snapshot with another max value",
+			a:    `{"IteratorType":"s","SnapshotLastProcessedVal":9,"SnapshotMaxValue":30,"SuffixName":"213315"}`,
+			b:    `{"IteratorType":"s","SnapshotLastProcessedVal":10,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+		},
+		{
+			name: "snapshot of character values",
+			a:    `{"IteratorType":"s","SnapshotLastProcessedVal":"a","SnapshotMaxValue":"z","SuffixName":"213315"}`,
+			b:    `{"IteratorType":"s","SnapshotLastProcessedVal":"b","SnapshotMaxValue":"z","SuffixName":"213315"}`,
+		},
+		{
+			name: "snapshot of decimal values",
+			a:    `{"IteratorType":"s","SnapshotLastProcessedVal":1.5,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+			b:    `{"IteratorType":"s","SnapshotLastProcessedVal":2.5,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+		},
+		{
+			name: "snapshot and cdc",
+			a:    `{"IteratorType":"s","SnapshotLastProcessedVal":1,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+			b:    `{"IteratorType":"c","CDCLastID":1,"SuffixName":"213315"}`,
+		},
+		{
+			name: "cdc of another tracking table",
+			a:    `{"IteratorType":"c","CDCLastID":5,"SuffixName":"100000"}`,
+			b:    `{"IteratorType":"c","CDCLastID":6,"SuffixName":"213315"}`,
+		},
+		{
+			name: "cdc without suffix",
+			a:    `{"IteratorType":"c","CDCLastID":5}`,
+			b:    `{"IteratorType":"c","CDCLastID":6}`,
+		},
+		{
+			name: "not a position of the source",
+			a:    "100",
+			b:    `{"IteratorType":"c","CDCLastID":6,"SuffixName":"213315"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Compare(opencdc.Position(tt.a), opencdc.Position(tt.b))
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("Compare() = %d, %t, want %d, %t", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDifferentRuns(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want bool
+	}{
+		{
+			name: "same snapshot",
+			a:    `{"IteratorType":"s","SnapshotLastProcessedVal":"a","SnapshotMaxValue":"z","SuffixName":"213315"}`,
+			b:    `{"IteratorType":"s","SnapshotLastProcessedVal":"b","SnapshotMaxValue":"z","SuffixName":"213315"}`,
+			want: false,
+		},
+		{
+			name: "same tracking table",
+			a:    `{"IteratorType":"c","CDCLastID":5,"SuffixName":"213315"}`,
+			b:    `{"IteratorType":"c","CDCLastID":6,"SuffixName":"213315"}`,
+			want: false,
+		},
+		{
+			name: "snapshot of another suffix",
+			a:    `{"IteratorType":"s","SnapshotLastProcessedVal":9,"SnapshotMaxValue":20,"SuffixName":"100000"}`,
+			b:    `{"IteratorType":"s","SnapshotLastProcessedVal":10,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+			want: true,
+		},
+		{
+			name: "snapshot with another max value",
+			a:    `{"IteratorType":"s","SnapshotLastProcessedVal":9,"SnapshotMaxValue":30,"SuffixName":"213315"}`,
+			b:    `{"IteratorType":"s","SnapshotLastProcessedVal":10,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+			want: true,
+		},
+		{
+			name: "snapshot and cdc",
+			a:    `{"IteratorType":"s","SnapshotLastProcessedVal":1,"SnapshotMaxValue":20,"SuffixName":"213315"}`,
+			b:    `{"IteratorType":"c","CDCLastID":1,"SuffixName":"213315"}`,
+			want: true,
+		},
+		{
+			name: "not a position of the source",
+			a:    "opaque",
+			b:    `{"IteratorType":"c","CDCLastID":6,"SuffixName":"213315"}`,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DifferentRuns(opencdc.Position(tt.a), opencdc.Position(tt.b)); got != tt.want {
+				t.Errorf("DifferentRuns() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
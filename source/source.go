@@ -18,6 +18,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/conduitio-labs/conduit-connector-db2/coltypes"
+	"github.com/conduitio-labs/conduit-connector-db2/common"
 	"github.com/conduitio-labs/conduit-connector-db2/source/config"
 	"github.com/conduitio-labs/conduit-connector-db2/source/iterator"
 	commonsConfig "github.com/conduitio/conduit-commons/config"
@@ -36,6 +38,10 @@ type Source struct {
 
 	config   config.Config
 	iterator Iterator
+	// db is the connection pool shared by the iterators, it is closed on teardown.
+	db *sqlx.DB
+	// retry retries reads failed with transient errors.
+	retry common.Retry
 }
 
 // NewSource initialises a new source.
@@ -72,23 +78,44 @@ func (s *Source) Configure(ctx context.Context, cfgRaw commonsConfig.Config) err
 
 // Open prepare the plugin to start sending records from the given position.
 func (s *Source) Open(ctx context.Context, rp opencdc.Position) error {
-	db, err := sqlx.Open("go_ibm_db", s.config.Connection)
+	loc, err := s.config.Location()
 	if err != nil {
-		return err
+		return fmt.Errorf("get location: %w", err)
 	}
 
+	conn, err := s.config.ConnectionString(ctx)
+	if err != nil {
+		return fmt.Errorf("connection string: %w", err)
+	}
+
+	db, err := common.Connect(ctx, conn, s.config.Pool(), s.config.SessionStatements()...)
+	if err != nil {
+		return fmt.Errorf("connect to db2: %w", err)
+	}
+
+	s.db = sqlx.NewDb(db, common.DriverName)
+	s.retry = s.config.Retry(db)
+
 	s.iterator, err = iterator.NewCombinedIterator(
 		ctx,
 		iterator.CombinedParams{
-			DB:             db,
-			Conn:           s.config.Connection,
+			DB:             s.db,
 			Table:          s.config.Table,
 			OrderingColumn: s.config.OrderingColumn,
 			CfgKeys:        s.config.PrimaryKeys,
 			Columns:        s.config.Columns,
 			BatchSize:      s.config.BatchSize,
-			Snapshot:       s.config.Snapshot,
+			Mode:           iterator.Mode(s.config.ReadMode()),
+			DecimalFormat:  coltypes.DecimalFormat(s.config.DecimalFormat),
+			TimeFormat:     coltypes.TimeFormat(s.config.TimeFormat),
+			Location:       loc,
+			LOBPolicies:    s.config.LOBPolicies(),
 			SdkPosition:    rp,
+			Dialect:        s.config.Dialect(),
+			Isolation:      common.Isolation(s.config.Isolation),
+			Filter:         s.config.Filter,
+			FilterTriggers: s.config.FilterTriggers,
+			Query:          s.config.Query,
 		},
 	)
 	if err != nil {
@@ -100,7 +127,15 @@ func (s *Source) Open(ctx context.Context, rp opencdc.Position) error {
 
 // Read gets the next object from the db2.
 func (s *Source) Read(ctx context.Context) (opencdc.Record, error) {
-	hasNext, err := s.iterator.HasNext(ctx)
+	var hasNext bool
+
+	// the iterators load the next batch from their position, so a failed load can be retried.
+	err := s.retry.Do(ctx, func() error {
+		var err error
+		hasNext, err = s.iterator.HasNext(ctx)
+
+		return err //nolint:wrapcheck // wrapped below
+	})
 	if err != nil {
 		return opencdc.Record{}, fmt.Errorf("source has next: %w", err)
 	}
@@ -119,14 +154,22 @@ func (s *Source) Read(ctx context.Context) (opencdc.Record, error) {
 
 // Teardown gracefully shutdown connector.
 func (s *Source) Teardown(context.Context) error {
+	var err error
+
 	if s.iterator != nil {
-		err := s.iterator.Stop()
-		if err != nil {
-			return err
+		err = s.iterator.Stop()
+	}
+
+	// the pool is closed after the iterators are stopped, so the tracking table is cleared the last time.
+	if s.db != nil {
+		if er := s.db.Close(); er != nil && err == nil {
+			err = fmt.Errorf("close db: %w", er)
 		}
+
+		s.db = nil
 	}
 
-	return nil
+	return err
 }
 
 // Ack check if record with position was recorded.
@@ -73,7 +73,7 @@ const (
 		DELETE FROM %s
 	`
 
-	queryFindTrackingTableName = `SELECT TABNAME FROM  SysCat.Tables WHERE TabName LIKE '%s_%%' LIMIT 1`
+	queryFindTrackingTableName = `SELECT TABNAME FROM  SysCat.Tables WHERE TabName LIKE '%s_%%' FETCH FIRST 1 ROWS ONLY`
 	queryDropTable             = `DROP TABLE IF EXISTS %s`
 )
 
@@ -443,6 +443,151 @@ func TestSource_Snapshot_Off(t *testing.T) {
 	}
 }
 
+func TestSource_Query(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tableName := randomIdentifier(t)
+
+	cfg, err := prepareConfig(tableName)
+	if err != nil {
+		t.Skip()
+	}
+
+	cfg[config.ConfigQuery] = fmt.Sprintf(`SELECT ID, CL1, CL8 * 2 AS DOUBLED FROM %s WHERE ID > 2`, tableName)
+	cfg[config.ConfigBatchSize] = "1"
+
+	err = prepareData(ctx, cfg[config.ConfigConnection], tableName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer clearData(ctx, cfg[config.ConfigConnection], cfg[config.ConfigTable]) // nolint:errcheck,nolintlint
+
+	s := NewSource()
+
+	err = s.Configure(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.Open(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the query result is read in batches of one row, the last record marks the end of the snapshot.
+	for _, want := range []struct {
+		payload   string
+		completed string
+	}{
+		{payload: `{"CL1":"varchar","DOUBLED":10910,"ID":3}`},
+		{payload: `{"CL1":"varchar","DOUBLED":10910,"ID":4}`, completed: "true"},
+	} {
+		r, er := s.Read(ctx)
+		if er != nil {
+			t.Fatal(er)
+		}
+
+		if string(r.Payload.After.Bytes()) != want.payload {
+			t.Fatalf("wrong record payload %s", r.Payload.After.Bytes())
+		}
+
+		if r.Metadata["db2.snapshot.completed"] != want.completed {
+			t.Fatalf("wrong snapshot completion metadata %q", r.Metadata["db2.snapshot.completed"])
+		}
+	}
+
+	// CDC is not started after the snapshot.
+	_, err = s.Read(ctx)
+	if err != sdk.ErrBackoffRetry {
+		t.Fatal(err)
+	}
+
+	err = s.Teardown(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSource_CDC_FilterTriggers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tableName := randomIdentifier(t)
+
+	cfg, err := prepareConfig(tableName)
+	if err != nil {
+		t.Skip()
+	}
+
+	cfg[config.ConfigFilter] = "CL8 > 100"
+	cfg[config.ConfigFilterTriggers] = "true"
+
+	err = prepareEmptyTable(ctx, cfg[config.ConfigConnection], tableName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer clearData(ctx, cfg[config.ConfigConnection], cfg[config.ConfigTable]) // nolint:errcheck,nolintlint
+
+	s := NewSource()
+
+	err = s.Configure(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.Open(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.Read(ctx)
+	if err != sdk.ErrBackoffRetry {
+		t.Fatal(err)
+	}
+
+	// the row is inserted into the filter, updated out of it, updated outside of it and updated into it again.
+	err = execQueries(ctx, cfg[config.ConfigConnection],
+		fmt.Sprintf(queryInsertCDCData, tableName),
+		fmt.Sprintf("UPDATE %s SET CL8 = 1 WHERE ID = 5", tableName),
+		fmt.Sprintf("UPDATE %s SET CL8 = 2 WHERE ID = 5", tableName),
+		fmt.Sprintf("UPDATE %s SET CL8 = 500 WHERE ID = 5", tableName),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []opencdc.Operation{
+		opencdc.OperationCreate,
+		opencdc.OperationDelete,
+		opencdc.OperationCreate,
+	} {
+		r, er := s.Read(ctx)
+		if er != nil {
+			t.Fatal(er)
+		}
+
+		if r.Operation != want {
+			t.Fatalf("wrong operation %s, want %s", r.Operation, want)
+		}
+	}
+
+	// the update outside of the filter is not captured.
+	_, err = s.Read(ctx)
+	if err != sdk.ErrBackoffRetry {
+		t.Fatal(err)
+	}
+
+	err = s.Teardown(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func prepareConfig(tableName string) (map[string]string, error) {
 	connection := os.Getenv("DB2_CONNECTION")
 
@@ -586,3 +731,20 @@ func randomIdentifier(t *testing.T) string {
 		strings.ReplaceAll(strings.ToLower(t.Name()), "/", "_"),
 		time.Now().UnixMicro()%1000))
 }
+
+func execQueries(ctx context.Context, conn string, queries ...string) error {
+	db, err := sql.Open("go_ibm_db", conn)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	for _, query := range queries {
+		if _, err = db.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}